@@ -0,0 +1,117 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Baseline interface {
+	Write(results domain.ErrorResults) error
+	Apply(results domain.ErrorResults) (domain.ErrorResults, error)
+}
+
+type Config struct {
+	Path string
+}
+
+type baseline struct {
+	config Config
+}
+
+func New(config Config) Baseline {
+	return baseline{config: config}
+}
+
+// Write 는 현재 비교 결과를 예상된 차이로 baseline 파일에 저장합니다.
+func (b baseline) Write(results domain.ErrorResults) error {
+	if b.config.Path == "" {
+		return errors.New("baseline path is required")
+	}
+
+	file := domain.BaselineFile{Entries: make([]domain.BaselineEntry, 0, len(results))}
+	for _, result := range results {
+		file.Entries = append(file.Entries, domain.NewBaselineEntry(result))
+	}
+
+	content, err := yaml.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	dirPath := path.Dir(b.config.Path)
+	if _, err = os.Stat(dirPath); os.IsNotExist(err) {
+		if err = os.MkdirAll(dirPath, 0755); err != nil {
+			return err
+		}
+	}
+
+	if err = os.WriteFile(b.config.Path, content, 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Baseline has been saved to %s\n", b.config.Path)
+
+	return nil
+}
+
+// Apply 는 baseline 과 키, 에러 코드, 양쪽 값(마스킹된 값은 원본 해시)이 모두 일치하는 결과를 제거하고,
+// 더 이상 발생하지 않는 baseline 항목을 BASELINE_STALE 결과로 추가합니다.
+func (b baseline) Apply(results domain.ErrorResults) (domain.ErrorResults, error) {
+	file, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make(map[domain.BaselineEntry]bool, len(file.Entries))
+	for _, entry := range file.Entries {
+		expected[entry.Identity()] = true
+	}
+
+	matched := make(map[domain.BaselineEntry]bool, len(file.Entries))
+	filtered := make(domain.ErrorResults, 0, len(results))
+	for _, result := range results {
+		entry := domain.NewBaselineEntry(result).Identity()
+		if expected[entry] {
+			matched[entry] = true
+			continue
+		}
+
+		filtered = append(filtered, result)
+	}
+
+	for _, entry := range file.Entries {
+		if matched[entry.Identity()] {
+			continue
+		}
+
+		filtered = append(filtered, domain.BaselineStaleResult(entry))
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].ErrorCode < filtered[j].ErrorCode
+	})
+
+	return filtered, nil
+}
+
+func (b baseline) read() (domain.BaselineFile, error) {
+	var file domain.BaselineFile
+
+	content, err := os.ReadFile(b.config.Path)
+	if err != nil {
+		return domain.BaselineFile{}, err
+	}
+
+	if err = yaml.Unmarshal(content, &file); err != nil {
+		return domain.BaselineFile{}, err
+	}
+
+	return file, nil
+}
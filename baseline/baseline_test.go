@@ -0,0 +1,99 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_baseline_Apply(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.yaml")
+	b := New(Config{Path: baselinePath})
+
+	known := domain.ValueUnmatchedResult("replicas", 1, 3)
+	removed := domain.KeyNotFoundResult("debug", true, nil)
+	assert.NoError(t, b.Write(domain.ErrorResults{known, removed}))
+
+	changed := domain.ValueUnmatchedResult("replicas", 1, 5)
+	added := domain.KeyNotFoundResult("feature", nil, "on")
+
+	tests := []struct {
+		name    string
+		results domain.ErrorResults
+		want    domain.ErrorResults
+	}{
+		{
+			name:    "일치하는 결과는 제거하고 사라진 항목은 stale 로 보고",
+			results: domain.ErrorResults{known, added},
+			want: domain.ErrorResults{
+				domain.BaselineStaleResult(domain.NewBaselineEntry(removed)),
+				added,
+			},
+		},
+		{
+			name:    "값이 바뀐 결과는 그대로 보고",
+			results: domain.ErrorResults{changed, removed},
+			want: domain.ErrorResults{
+				domain.BaselineStaleResult(domain.NewBaselineEntry(known)),
+				changed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.Apply(tt.results)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_baseline_Apply_masked(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.yaml")
+	b := New(Config{Path: baselinePath})
+
+	known := domain.ValueUnmatchedResult("db.password",
+		domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "aaaaaaaa"},
+		domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "bbbbbbbb"},
+	)
+	assert.NoError(t, b.Write(domain.ErrorResults{known}))
+
+	restyled := domain.ValueUnmatchedResult("db.password",
+		domain.MaskedValue{Type: "string", Display: "sha256:aaaaaaaa", Digest: "aaaaaaaa"},
+		domain.MaskedValue{Type: "string", Display: "sha256:bbbbbbbb", Digest: "bbbbbbbb"},
+	)
+	changed := domain.ValueUnmatchedResult("db.password",
+		domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "aaaaaaaa"},
+		domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "cccccccc"},
+	)
+
+	tests := []struct {
+		name    string
+		results domain.ErrorResults
+		want    domain.ErrorResults
+	}{
+		{
+			name:    "표시 방식이 달라도 원본 해시가 같으면 일치",
+			results: domain.ErrorResults{restyled},
+			want:    domain.ErrorResults{},
+		},
+		{
+			name:    "원본 값이 바뀐 마스킹된 값은 그대로 보고",
+			results: domain.ErrorResults{changed},
+			want: domain.ErrorResults{
+				domain.BaselineStaleResult(domain.NewBaselineEntry(known)),
+				changed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.Apply(tt.results)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package catalog
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLanguage 는 선택한 언어에 메시지가 없을 때 사용하는 언어입니다.
+const DefaultLanguage domain.ReportLanguage = "en"
+
+// Form 은 에러 코드 메시지의 형식입니다. domain.CodeMessages 의 각 필드에 대응합니다.
+type Form string
+
+const (
+	Title       Form = "title"
+	Description Form = "description"
+	Line        Form = "line"
+)
+
+//go:embed messages/*.yaml
+var embedded embed.FS
+
+// Args 는 메시지의 {name} 을 치환할 값입니다.
+type Args map[string]string
+
+type Catalog interface {
+	// Code 는 에러 코드의 form 형식 메시지를 반환합니다.
+	Code(code domain.ErrorCode, form Form, args Args) (string, error)
+	// Message 는 에러 코드 외의 메시지를 반환합니다.
+	Message(id string, args Args) string
+}
+
+type Config struct {
+	Language domain.ReportLanguage
+	// Paths 는 사용자 카탈로그 파일 경로입니다. 내장 카탈로그와 같은 언어인 경우 지정한 메시지만 덮어씁니다.
+	Paths []string
+}
+
+type catalog struct {
+	language domain.ReportLanguage
+	catalogs map[domain.ReportLanguage]domain.MessageCatalog
+}
+
+// Load 는 내장 카탈로그와 사용자 카탈로그 파일을 읽고, 선택한 언어의 카탈로그가 있는지 확인합니다.
+func Load(config Config) (Catalog, error) {
+	catalogs, err := builtins()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, filePath := range config.Paths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		loaded, err := parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+		catalogs[loaded.Language] = merge(catalogs[loaded.Language], loaded)
+	}
+
+	if _, ok := catalogs[config.Language]; !ok {
+		return nil, fmt.Errorf("unsupported language: %s (available: %s)", config.Language, strings.Join(languages(catalogs), ", "))
+	}
+
+	return catalog{language: config.Language, catalogs: catalogs}, nil
+}
+
+// Default 는 내장 카탈로그만 사용하는 카탈로그를 반환합니다. 지원하지 않는 언어는 영어 메시지를 사용합니다.
+func Default(language domain.ReportLanguage) Catalog {
+	catalogs, err := builtins()
+	if err != nil {
+		panic(err)
+	}
+
+	return catalog{language: language, catalogs: catalogs}
+}
+
+func builtins() (map[domain.ReportLanguage]domain.MessageCatalog, error) {
+	entries, err := embedded.ReadDir("messages")
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := map[domain.ReportLanguage]domain.MessageCatalog{}
+	for _, entry := range entries {
+		content, err := embedded.ReadFile(path.Join("messages", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		loaded, err := parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		catalogs[loaded.Language] = loaded
+	}
+
+	return catalogs, nil
+}
+
+func parse(content []byte) (domain.MessageCatalog, error) {
+	var loaded domain.MessageCatalog
+	if err := yaml.Unmarshal(content, &loaded); err != nil {
+		return domain.MessageCatalog{}, err
+	}
+
+	if loaded.Language == "" {
+		return domain.MessageCatalog{}, errors.New("language is required")
+	}
+
+	return loaded, nil
+}
+
+// merge 는 base 위에 override 에 지정된 메시지를 덮어씁니다.
+func merge(base domain.MessageCatalog, override domain.MessageCatalog) domain.MessageCatalog {
+	merged := domain.MessageCatalog{
+		Language: override.Language,
+		Codes:    map[domain.ErrorCode]domain.CodeMessages{},
+		Messages: map[string]string{},
+	}
+
+	for code, messages := range base.Codes {
+		merged.Codes[code] = messages
+	}
+	for code, messages := range override.Codes {
+		current := merged.Codes[code]
+		if messages.Title != "" {
+			current.Title = messages.Title
+		}
+		if messages.Description != "" {
+			current.Description = messages.Description
+		}
+		if messages.Line != "" {
+			current.Line = messages.Line
+		}
+		merged.Codes[code] = current
+	}
+
+	for id, message := range base.Messages {
+		merged.Messages[id] = message
+	}
+	for id, message := range override.Messages {
+		merged.Messages[id] = message
+	}
+
+	return merged
+}
+
+func languages(catalogs map[domain.ReportLanguage]domain.MessageCatalog) []string {
+	names := make([]string, 0, len(catalogs))
+	for language := range catalogs {
+		names = append(names, string(language))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Code 는 선택한 언어, 영어 순서로 메시지를 찾습니다.
+// title, line 형식이 없는 경우 같은 언어의 description 을 사용합니다.
+func (c catalog) Code(code domain.ErrorCode, form Form, args Args) (string, error) {
+	for _, language := range []domain.ReportLanguage{c.language, DefaultLanguage} {
+		messages, ok := c.catalogs[language].Codes[code]
+		if !ok {
+			continue
+		}
+
+		var message string
+		switch form {
+		case Title:
+			message = messages.Title
+		case Description:
+			message = messages.Description
+		case Line:
+			message = messages.Line
+		}
+
+		switch {
+		case message != "":
+			return format(message, args), nil
+		case messages.Description == "":
+			continue
+		case form == Line:
+			return format("[{key}]"+messages.Description, args), nil
+		default:
+			return format(messages.Description, args), nil
+		}
+	}
+
+	return "", errors.New("unsupported error code")
+}
+
+func (c catalog) Message(id string, args Args) string {
+	message, ok := c.catalogs[c.language].Messages[id]
+	if !ok {
+		message = c.catalogs[DefaultLanguage].Messages[id]
+	}
+
+	return format(message, args)
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// format 은 message 의 {name} 을 args 의 값으로 치환합니다. args 에 없는 이름은 그대로 둡니다.
+func format(message string, args Args) string {
+	return placeholder.ReplaceAllStringFunc(message, func(match string) string {
+		if value, ok := args[match[1:len(match)-1]]; ok {
+			return value
+		}
+		return match
+	})
+}
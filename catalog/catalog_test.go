@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_catalog_Code(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ja.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`language: ja
+codes:
+  VALUE_UNMATCHED:
+    description: "値が一致しません。{lhs}: {lhsValue}, {rhs}: {rhsValue}"
+`), 0644))
+
+	args := Args{"key": "a.b", "lhs": "dev", "rhs": "prod", "lhsValue": "1", "rhsValue": "2", "side": "prod"}
+
+	tests := []struct {
+		name     string
+		language domain.ReportLanguage
+		code     domain.ErrorCode
+		form     Form
+		want     string
+	}{
+		{name: "embedded english", language: "en", code: domain.ErrorKeyNotFound, form: Line, want: "Key not found in prod. key:[a.b]"},
+		{name: "embedded korean", language: "ko", code: domain.ErrorKeyNotFound, form: Description, want: "키가 존재하지 않습니다. prod"},
+		{name: "user catalog", language: "ja", code: domain.ErrorValueUnmatched, form: Description, want: "値が一致しません。dev: 1, prod: 2"},
+		{name: "line falls back to description", language: "ja", code: domain.ErrorValueUnmatched, form: Line, want: "[a.b]値が一致しません。dev: 1, prod: 2"},
+		{name: "missing code falls back to english", language: "ja", code: domain.ErrorKeyNotFound, form: Title, want: "Key not found."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Load(Config{Language: tt.language, Paths: []string{path}})
+			assert.NoError(t, err)
+
+			got, err := c.Code(tt.code, tt.form, args)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := Default("en").Code("UNKNOWN", Description, args)
+	assert.EqualError(t, err, "unsupported error code")
+}
+
+func Test_Load(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ko.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`language: ko
+messages:
+  differences: "차이 {count}건"
+`), 0644))
+
+	c, err := Load(Config{Language: "ko", Paths: []string{path}})
+	assert.NoError(t, err)
+	assert.Equal(t, "차이 3건", c.Message("differences", Args{"count": "3"}))
+	assert.Equal(t, "⚠️ 표시는 다수의 환경과 값이 다른 환경입니다.", c.Message("matrixMajority", nil))
+
+	_, err = Load(Config{Language: "de"})
+	assert.EqualError(t, err, "unsupported language: de (available: en, ko)")
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalidPath, []byte("codes: {}\n"), 0644))
+	_, err = Load(Config{Language: "en", Paths: []string{invalidPath}})
+	assert.EqualError(t, err, invalidPath+": language is required")
+}
+
+func Test_format(t *testing.T) {
+	assert.Equal(t, "dev: {x}, {unknown}", format("{lhs}: {value}, {unknown}", Args{"lhs": "dev", "value": "{x}"}))
+}
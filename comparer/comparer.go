@@ -2,10 +2,14 @@ package comparer
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/illuminarean-labs/yaml-diff-reporter/masker"
 	"github.com/samber/lo"
 )
 
@@ -14,17 +18,29 @@ const (
 	Key   domain.CompareMode = "key"
 	Index domain.CompareMode = "index"
 	Value domain.CompareMode = "value"
+
+	// Subset 은 rhs 에만 존재하는 키와 인덱스를 보고하지 않는 방향성 있는 비교 모드입니다.
+	Subset domain.CompareMode = "subset"
+	// Pattern 은 "regex:" 로 시작하는 lhs 문자열 값을 rhs 값이 만족해야 하는 정규 표현식으로 해석합니다.
+	Pattern domain.CompareMode = "pattern"
 )
 
+const patternPrefix = "regex:"
+
 type Comparer interface {
 	Compare(currentKey string, lhs any, rhs any)
+	CompareThreeWay(currentKey string, base any, lhs any, rhs any)
+	CompareMatrix(documents []domain.Document, reference string) domain.Matrix
 	Results() *domain.ErrorResults
+	Stats() domain.CompareStats
 }
 
 func New(config Config) Comparer {
 	c := comparer{
 		results: &domain.ErrorResults{},
 		config:  config,
+		rules:   compileRules(config.Rules),
+		stats:   &domain.CompareStats{},
 	}
 
 	return c
@@ -33,11 +49,16 @@ func New(config Config) Comparer {
 type Config struct {
 	IgnoredKeys []string
 	Modes       domain.CompareModes
+	Rules       domain.Rules
+	// Masker 가 지정된 경우 원본 값으로 비교하고, 결과에 기록하는 값만 마스킹합니다.
+	Masker masker.Masker
 }
 
 type comparer struct {
 	results *domain.ErrorResults
 	config  Config
+	rules   []compiledRule
+	stats   *domain.CompareStats
 }
 
 func mapKey(parent string, key string) string {
@@ -53,15 +74,33 @@ func sliceKey(parent string, index int) string {
 }
 
 func (c comparer) Compare(parent string, lhs any, rhs any) {
-	if lo.Contains(c.config.IgnoredKeys, parent) {
+	p := c.policy(parent)
+	if p.ignore {
+		return
+	}
+
+	if p.expected != nil && reflect.DeepEqual(p.expected.LHS, lhs) && reflect.DeepEqual(p.expected.RHS, rhs) {
 		return
 	}
 
+	if p.hasMode(Pattern) {
+		if pattern, ok := constraintPattern(lhs); ok {
+			if p.hasMode(Value) && !matchPattern(pattern, rhs) {
+				c.addResult(p, domain.ValueUnmatchedResult(parent, c.display(parent, lhs), c.display(parent, rhs)))
+			}
+			return
+		}
+	}
+
 	lhsType := reflect.TypeOf(lhs)
 	rhsType := reflect.TypeOf(rhs)
 
-	if lo.Contains(c.config.Modes, Type) && lhsType != rhsType {
-		*c.results = append(*c.results, domain.TypeUnmatchedResult(parent, lhs, rhs))
+	if p.tolerance > 0 && withinTolerance(lhs, rhs, p.tolerance) {
+		return
+	}
+
+	if p.hasMode(Type) && lhsType != rhsType {
+		c.addResult(p, domain.TypeUnmatchedResult(parent, c.display(parent, lhs), c.display(parent, rhs)))
 		return
 	}
 
@@ -73,10 +112,18 @@ func (c comparer) Compare(parent string, lhs any, rhs any) {
 	case []any:
 		lhsArr, _ := lhs.([]any)
 		rhsArr, _ := rhs.([]any)
-		c.compareSlice(parent, lhsArr, rhsArr)
+		c.stats.ArraysCompared++
+		switch p.arrayStrategy {
+		case UnorderedStrategy:
+			c.compareUnorderedSlice(parent, lhsArr, rhsArr)
+		case KeyStrategy:
+			c.compareKeyedSlice(parent, p.arrayKey, lhsArr, rhsArr)
+		default:
+			c.compareSlice(parent, lhsArr, rhsArr)
+		}
 	default:
-		if lo.Contains(c.config.Modes, Value) && !reflect.DeepEqual(lhs, rhs) {
-			*c.results = append(*c.results, domain.ValueUnmatchedResult(parent, lhs, rhs))
+		if p.hasMode(Value) && !reflect.DeepEqual(lhs, rhs) {
+			c.addResult(p, domain.ValueUnmatchedResult(parent, c.display(parent, lhs), c.display(parent, rhs)))
 		}
 	}
 }
@@ -91,16 +138,17 @@ func (c comparer) compareMap(parent string, lhs map[string]any, rhs map[string]a
 	visited := make(map[string]bool)
 
 	for key, lhsVal = range lhs {
+		visited[key] = true
 		nextKey := mapKey(parent, key)
-		if lo.Contains(c.config.IgnoredKeys, nextKey) {
+		p := c.policy(nextKey)
+		if c.skip(p) {
 			continue
 		}
 
-		visited[key] = true
 		rhsVal, ok = rhs[key]
 		if !ok {
-			if lo.Contains(c.config.Modes, Key) {
-				*c.results = append(*c.results, domain.KeyNotFoundResult(nextKey, lhsVal, nil))
+			if p.hasMode(Key) {
+				c.addResult(p, domain.KeyNotFoundResult(nextKey, c.display(nextKey, lhsVal), nil))
 			}
 
 			continue
@@ -109,19 +157,20 @@ func (c comparer) compareMap(parent string, lhs map[string]any, rhs map[string]a
 		c.Compare(nextKey, lhsVal, rhsVal)
 	}
 	for key, rhsVal = range rhs {
-		nextKey := mapKey(parent, key)
-		if lo.Contains(c.config.IgnoredKeys, nextKey) {
+		if visited[key] {
 			continue
 		}
 
-		if visited[key] {
+		nextKey := mapKey(parent, key)
+		p := c.policy(nextKey)
+		if c.skip(p) {
 			continue
 		}
 
 		lhsVal, ok = lhs[key]
 		if !ok {
-			if lo.Contains(c.config.Modes, Key) {
-				*c.results = append(*c.results, domain.KeyNotFoundResult(nextKey, nil, rhsVal))
+			if p.hasMode(Key) && !p.hasMode(Subset) {
+				c.addResult(p, domain.KeyNotFoundResult(nextKey, nil, c.display(nextKey, rhsVal)))
 			}
 		}
 	}
@@ -136,15 +185,16 @@ func (c comparer) compareSlice(parent string, lhs []any, rhs []any) {
 
 	visited := make(map[int]bool)
 	for idx, lhsVal = range lhs {
+		visited[idx] = true
 		nextKey := sliceKey(parent, idx)
-		if lo.Contains(c.config.IgnoredKeys, nextKey) {
+		p := c.policy(nextKey)
+		if c.skip(p) {
 			continue
 		}
 
-		visited[idx] = true
 		if len(rhs) <= idx {
-			if lo.Contains(c.config.Modes, Index) {
-				*c.results = append(*c.results, domain.IndexNotFoundResult(nextKey, lhsVal, nil))
+			if p.hasMode(Index) {
+				c.addResult(p, domain.IndexNotFoundResult(nextKey, c.display(nextKey, lhsVal), nil))
 			}
 
 			continue
@@ -155,22 +205,223 @@ func (c comparer) compareSlice(parent string, lhs []any, rhs []any) {
 	}
 
 	for idx, rhsVal = range rhs {
-		nextKey := sliceKey(parent, idx)
-		if lo.Contains(c.config.IgnoredKeys, nextKey) {
+		if visited[idx] {
 			continue
 		}
-		if visited[idx] {
+
+		nextKey := sliceKey(parent, idx)
+		p := c.policy(nextKey)
+		if c.skip(p) {
 			continue
 		}
 
 		if len(lhs) <= idx {
-			if lo.Contains(c.config.Modes, Index) {
-				*c.results = append(*c.results, domain.IndexNotFoundResult(nextKey, nil, rhsVal))
+			if p.hasMode(Index) && !p.hasMode(Subset) {
+				c.addResult(p, domain.IndexNotFoundResult(nextKey, nil, c.display(nextKey, rhsVal)))
 			}
 		}
 	}
 }
 
+// compareUnorderedSlice 는 순서와 관계없이 같은 값을 가진 요소끼리 짝지어 비교합니다.
+// 값 전체가 같은 요소만 짝지으므로, 필드 하나만 바뀐 맵 요소는 값 변경이 아닌 양쪽의 INDEX_NOT_FOUND 로 보고됩니다.
+// 맵 요소의 변경을 필드 단위로 비교하려면 key 전략을 사용합니다.
+func (c comparer) compareUnorderedSlice(parent string, lhs []any, rhs []any) {
+	matched := make(map[int]bool)
+
+	for lhsIdx, lhsVal := range lhs {
+		nextKey := sliceKey(parent, lhsIdx)
+		p := c.policy(nextKey)
+		if c.skip(p) {
+			continue
+		}
+
+		rhsIdx, ok := lo.Find(lo.Range(len(rhs)), func(idx int) bool {
+			return !matched[idx] && reflect.DeepEqual(lhsVal, rhs[idx])
+		})
+		if ok {
+			matched[rhsIdx] = true
+			continue
+		}
+
+		if p.hasMode(Index) {
+			c.addResult(p, domain.IndexNotFoundResult(nextKey, c.display(nextKey, lhsVal), nil))
+		}
+	}
+
+	for rhsIdx, rhsVal := range rhs {
+		if matched[rhsIdx] {
+			continue
+		}
+
+		nextKey := sliceKey(parent, rhsIdx)
+		p := c.policy(nextKey)
+		if c.skip(p) || p.hasMode(Subset) {
+			continue
+		}
+
+		if p.hasMode(Index) {
+			c.addResult(p, domain.IndexNotFoundResult(nextKey, nil, c.display(nextKey, rhsVal)))
+		}
+	}
+}
+
+// compareKeyedSlice 는 arrayKey 값이 같은 맵 요소끼리 짝지어 비교합니다.
+// 짝지어진 요소의 경로는 lhs 의 인덱스를 사용합니다.
+func (c comparer) compareKeyedSlice(parent string, arrayKey string, lhs []any, rhs []any) {
+	matched := make(map[int]bool)
+
+	for lhsIdx, lhsVal := range lhs {
+		nextKey := sliceKey(parent, lhsIdx)
+		p := c.policy(nextKey)
+		if c.skip(p) {
+			continue
+		}
+
+		rhsIdx := findByArrayKey(rhs, arrayKey, lhsVal, matched)
+		if rhsIdx < 0 {
+			if p.hasMode(Index) {
+				c.addResult(p, domain.IndexNotFoundResult(nextKey, c.display(nextKey, lhsVal), nil))
+			}
+
+			continue
+		}
+
+		matched[rhsIdx] = true
+		c.Compare(nextKey, lhsVal, rhs[rhsIdx])
+	}
+
+	for rhsIdx, rhsVal := range rhs {
+		if matched[rhsIdx] {
+			continue
+		}
+
+		nextKey := sliceKey(parent, rhsIdx)
+		p := c.policy(nextKey)
+		if c.skip(p) || p.hasMode(Subset) {
+			continue
+		}
+
+		if p.hasMode(Index) {
+			c.addResult(p, domain.IndexNotFoundResult(nextKey, nil, c.display(nextKey, rhsVal)))
+		}
+	}
+}
+
+func findByArrayKey(list []any, arrayKey string, target any, matched map[int]bool) int {
+	targetMap, ok := target.(map[string]any)
+	if !ok {
+		return -1
+	}
+
+	targetKey, ok := targetMap[arrayKey]
+	if !ok {
+		return -1
+	}
+
+	for idx, elem := range list {
+		elemMap, ok := elem.(map[string]any)
+		if !ok || matched[idx] {
+			continue
+		}
+
+		if reflect.DeepEqual(elemMap[arrayKey], targetKey) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// constraintPattern 은 "regex:" 로 시작하는 문자열을 정규 표현식으로 변환합니다.
+// 올바른 정규 표현식이 아닌 경우 일반 문자열 값으로 비교되도록 false 를 반환합니다.
+func constraintPattern(value any) (*regexp.Regexp, bool) {
+	str, ok := value.(string)
+	if !ok || !strings.HasPrefix(str, patternPrefix) {
+		return nil, false
+	}
+
+	pattern, err := regexp.Compile(strings.TrimPrefix(str, patternPrefix))
+	if err != nil {
+		return nil, false
+	}
+
+	return pattern, true
+}
+
+// matchPattern 은 스칼라 값의 문자열 표현이 정규 표현식을 만족하는지 확인합니다.
+func matchPattern(pattern *regexp.Regexp, value any) bool {
+	switch value.(type) {
+	case nil, map[string]any, []any:
+		return false
+	default:
+		return pattern.MatchString(fmt.Sprint(value))
+	}
+}
+
+// withinTolerance 는 두 값이 모두 숫자이고 차이가 tolerance 이하인지 확인합니다.
+func withinTolerance(lhs any, rhs any, tolerance float64) bool {
+	lhsNum, ok := toFloat(lhs)
+	if !ok {
+		return false
+	}
+
+	rhsNum, ok := toFloat(rhs)
+	if !ok {
+		return false
+	}
+
+	return math.Abs(lhsNum-rhsNum) <= tolerance
+}
+
+func toFloat(value any) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case uint64:
+		return float64(value), true
+	case float64:
+		return value, true
+	default:
+		return 0, false
+	}
+}
+
+// display 는 결과에 기록할 값을 반환합니다. Masker 가 지정된 경우 마스킹된 값을 반환합니다.
+func (c comparer) display(key string, value any) any {
+	if c.config.Masker == nil {
+		return value
+	}
+
+	return c.config.Masker.MaskValue(key, value)
+}
+
+// addResult 는 경로 정책에 심각도가 지정된 경우 기본 심각도를 덮어쓴 뒤 결과를 추가합니다.
+func (c comparer) addResult(p policy, result domain.ErrorResult) {
+	if p.severity != "" {
+		result.Severity = p.severity
+	}
+
+	*c.results = append(*c.results, result)
+}
+
+// skip 은 경로를 방문한 것으로 집계하고, 무시할 경로인 경우 true 를 반환합니다.
+func (c comparer) skip(p policy) bool {
+	if p.ignore {
+		c.stats.IgnoredPaths++
+		return true
+	}
+
+	c.stats.KeysVisited++
+	return false
+}
+
+func (c comparer) Stats() domain.CompareStats {
+	return *c.stats
+}
+
 func (c comparer) Results() *domain.ErrorResults {
 	sort.SliceStable(*c.results, func(i, j int) bool {
 		return (*c.results)[i].ErrorCode < (*c.results)[j].ErrorCode
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/illuminarean-labs/yaml-diff-reporter/masker"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +32,7 @@ func TestNew(t *testing.T) {
 					IgnoredKeys: []string{"hello"},
 					Modes:       domain.CompareModes{Type, Value},
 				},
+				stats: &domain.CompareStats{},
 			},
 		},
 	}
@@ -130,8 +132,97 @@ func Test_comparer_Compare(t *testing.T) {
 			c := comparer{
 				results: tt.fields.Results,
 				config:  tt.fields.Config,
+				stats:   &domain.CompareStats{},
 			}
 			c.Compare(tt.args.parent, tt.args.lhs, tt.args.rhs)
 		})
 	}
 }
+
+func Test_comparer_Compare_subset(t *testing.T) {
+	type args struct {
+		lhs any
+		rhs any
+	}
+	tests := []struct {
+		name  string
+		modes domain.CompareModes
+		args  args
+		want  domain.ErrorResults
+	}{
+		{
+			name:  "rhs 에만 존재하는 키와 인덱스는 보고하지 않음",
+			modes: domain.CompareModes{Type, Key, Index, Value, Subset},
+			args: args{
+				lhs: map[string]any{"a": 1, "list": []any{1}, "missing": true},
+				rhs: map[string]any{"a": 1, "b": 2, "list": []any{1, 2}},
+			},
+			want: domain.ErrorResults{
+				domain.KeyNotFoundResult("missing", true, nil),
+			},
+		},
+		{
+			name:  "정규 표현식 제약",
+			modes: domain.CompareModes{Type, Key, Index, Value, Subset, Pattern},
+			args: args{
+				lhs: map[string]any{"image": `regex:^nginx:1\.\d+$`, "port": `regex:^\d+$`, "tag": `regex:^v`},
+				rhs: map[string]any{"image": "nginx:1.25", "port": 8080, "tag": "latest"},
+			},
+			want: domain.ErrorResults{
+				domain.ValueUnmatchedResult("tag", `regex:^v`, "latest"),
+			},
+		},
+		{
+			name:  "pattern 모드가 아니면 일반 문자열로 비교",
+			modes: domain.CompareModes{Type, Key, Index, Value, Subset},
+			args: args{
+				lhs: map[string]any{"tag": `regex:^v`},
+				rhs: map[string]any{"tag": "v1"},
+			},
+			want: domain.ErrorResults{
+				domain.ValueUnmatchedResult("tag", `regex:^v`, "v1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(Config{Modes: tt.modes})
+			c.Compare("", tt.args.lhs, tt.args.rhs)
+			assert.Equal(t, tt.want, *c.Results())
+		})
+	}
+}
+
+func Test_comparer_Stats(t *testing.T) {
+	c := New(Config{
+		IgnoredKeys: []string{"ignored"},
+		Modes:       domain.CompareModes{Type, Key, Index, Value},
+	})
+
+	c.Compare("",
+		map[string]any{"a": 1, "list": []any{1, 2}, "ignored": true, "lhsOnly": 1},
+		map[string]any{"a": 1, "list": []any{1}, "ignored": false, "rhsOnly": 1},
+	)
+
+	assert.Equal(t, domain.CompareStats{KeysVisited: 6, ArraysCompared: 1, IgnoredPaths: 1}, c.Stats())
+}
+
+func Test_comparer_Masker(t *testing.T) {
+	c := New(Config{
+		Modes:  domain.CompareModes{Type, Key, Index, Value, Pattern},
+		Masker: masker.New(masker.Config{Style: masker.Redact, DigestKey: "test-key"}),
+	})
+
+	c.Compare("",
+		map[string]any{"image": "app", "db": map[string]any{"password": "regex:^.{8,}$"}, "token": "a"},
+		map[string]any{"image": "Zx8Qp2Lm9Vt4Rk7Ws1Yb3Nc6", "db": map[string]any{"password": "longenoughpw"}, "token": "b"},
+	)
+
+	assert.ElementsMatch(t, domain.ErrorResults{
+		domain.ValueUnmatchedResult("image", "app", domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "8434ff86"}),
+		domain.ValueUnmatchedResult("token",
+			domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "2a2a83b1"},
+			domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "11810610"},
+		),
+	}, *c.Results())
+}
@@ -0,0 +1,205 @@
+package comparer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+// CompareMatrix 는 첫 번째 문서(또는 기준 문서)와 나머지 문서를 각각 Compare 로 비교해
+// 어느 한 곳에서라도 다른 경로를 찾고, 경로별로 다수 값 또는 기준 값과 다른 환경을 표시합니다.
+// 값은 원본 값으로 비교하고, 셀에 표시하는 값만 Masker 로 마스킹합니다.
+func (c comparer) CompareMatrix(documents []domain.Document, reference string) domain.Matrix {
+	matrix := domain.Matrix{Reference: reference}
+	if len(documents) == 0 {
+		return matrix
+	}
+
+	referenceIdx := 0
+	for idx, document := range documents {
+		if document.Alias == reference {
+			referenceIdx = idx
+		}
+	}
+
+	var rows []matrixKey
+	seen := make(map[string]bool)
+	for idx, document := range documents {
+		if idx == referenceIdx {
+			continue
+		}
+
+		for _, result := range c.changedResults("", documents[referenceIdx].Values, document.Values) {
+			// key 배열 전략에서 기준 문서에 없는 요소의 마지막 인덱스는 비교 대상 문서 기준입니다.
+			origin := referenceIdx
+			if result.ErrorCode == domain.ErrorIndexNotFound && result.FindNilSide() == "LHS" {
+				origin = idx
+			}
+
+			row := matrixKey{key: result.Key}
+			for target := range documents {
+				key, ok := c.alignKey(documents, referenceIdx, origin, target, result.Key)
+				row.keys = append(row.keys, key)
+				row.aligned = append(row.aligned, ok)
+			}
+
+			identity := row.identity()
+			if seen[identity] {
+				continue
+			}
+			seen[identity] = true
+			rows = append(rows, row)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].key < rows[j].key
+	})
+
+	for _, key := range rows {
+		values := make([]any, len(documents))
+		present := make([]bool, len(documents))
+		for idx, document := range documents {
+			if key.aligned[idx] {
+				values[idx], present[idx] = domain.Lookup(document.Values, key.keys[idx])
+			}
+		}
+
+		expected := referenceIdx
+		if reference == "" {
+			expected = majority(values, present, referenceIdx)
+		}
+
+		row := domain.MatrixRow{Key: key.key}
+		for idx := range documents {
+			cell := domain.MatrixCell{Present: present[idx]}
+			if present[idx] {
+				cell.Entry = domain.NewYAMLEntry(c.display(key.keys[idx], values[idx]))
+			}
+			cell.Deviates = !sameValue(values[idx], present[idx], values[expected], present[expected])
+			row.Cells = append(row.Cells, cell)
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+
+	return matrix
+}
+
+// matrixKey 는 매트릭스 행의 경로와, 각 문서에서 같은 값을 가리키도록 배열 인덱스를 맞춘 경로입니다.
+type matrixKey struct {
+	key     string
+	keys    []string
+	aligned []bool
+}
+
+// identity 는 여러 문서와의 비교에서 같은 행이 중복되지 않도록 문서별 경로를 하나의 문자열로 합칩니다.
+func (k matrixKey) identity() string {
+	parts := make([]string, len(k.keys))
+	for idx := range k.keys {
+		parts[idx] = fmt.Sprintf("%t:%s", k.aligned[idx], k.keys[idx])
+	}
+
+	return strings.Join(parts, "\x00")
+}
+
+// alignKey 는 Compare 가 보고한 key 를 target 문서에서 같은 요소를 가리키는 경로로 바꿉니다.
+// key 의 배열 인덱스는 기준 문서 기준이며, origin 이 기준 문서가 아닌 경우 마지막 인덱스만 origin 문서 기준입니다.
+// key 배열 전략이 적용된 배열은 arrayKey 값이 같은 요소의 인덱스로 바꾸고, 그런 요소가 없으면 false 를 반환합니다.
+func (c comparer) alignKey(documents []domain.Document, reference int, origin int, target int, key string) (string, bool) {
+	segments := domain.SplitPath(key)
+	referenceKey, originKey, targetKey := "", "", ""
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "[") {
+			referenceKey = domain.MapKey(referenceKey, segment)
+			originKey = domain.MapKey(originKey, segment)
+			targetKey = domain.MapKey(targetKey, segment)
+			continue
+		}
+
+		p := c.policy(referenceKey)
+		if p.arrayStrategy != KeyStrategy {
+			referenceKey, originKey, targetKey = referenceKey+segment, originKey+segment, targetKey+segment
+			continue
+		}
+
+		index, err := strconv.Atoi(strings.Trim(segment, "[]"))
+		if err != nil {
+			return "", false
+		}
+
+		source, sourceKey := reference, referenceKey
+		if i == len(segments)-1 {
+			source, sourceKey = origin, originKey
+		}
+
+		list, _ := domain.Lookup(documents[source].Values, sourceKey)
+		elems, ok := list.([]any)
+		if !ok || index >= len(elems) {
+			return "", false
+		}
+
+		if i < len(segments)-1 {
+			originIdx, ok := alignIndex(documents[origin].Values, originKey, p.arrayKey, elems[index], origin == source, index)
+			if !ok {
+				return "", false
+			}
+			originKey = domain.SliceKey(originKey, originIdx)
+		}
+
+		targetIdx, ok := alignIndex(documents[target].Values, targetKey, p.arrayKey, elems[index], target == source, index)
+		if !ok {
+			return "", false
+		}
+		referenceKey = domain.SliceKey(referenceKey, index)
+		targetKey = domain.SliceKey(targetKey, targetIdx)
+	}
+
+	return targetKey, true
+}
+
+// alignIndex 는 document 의 key 배열에서 elem 과 arrayKey 값이 같은 요소의 인덱스를 찾습니다.
+// elem 이 document 의 요소인 경우 arrayKey 가 없거나 중복되더라도 elem 의 인덱스를 그대로 사용합니다.
+func alignIndex(document any, key string, arrayKey string, elem any, same bool, index int) (int, bool) {
+	if same {
+		return index, true
+	}
+
+	list, _ := domain.Lookup(document, key)
+	elems, ok := list.([]any)
+	if !ok {
+		return 0, false
+	}
+
+	idx := findByArrayKey(elems, arrayKey, elem, nil)
+	return idx, idx >= 0
+}
+
+// majority 는 가장 많은 문서가 가진 값의 인덱스를 반환합니다. 동률인 경우 기본 인덱스의 값을 우선합니다.
+func majority(values []any, present []bool, fallback int) int {
+	best, bestCount := fallback, 0
+	for idx := range values {
+		count := 0
+		for other := range values {
+			if sameValue(values[idx], present[idx], values[other], present[other]) {
+				count++
+			}
+		}
+
+		if count > bestCount || (count == bestCount && sameValue(values[idx], present[idx], values[fallback], present[fallback])) {
+			best, bestCount = idx, count
+		}
+	}
+
+	return best
+}
+
+func sameValue(lhs any, lhsPresent bool, rhs any, rhsPresent bool) bool {
+	if lhsPresent != rhsPresent {
+		return false
+	}
+
+	return reflect.DeepEqual(lhs, rhs)
+}
@@ -0,0 +1,143 @@
+package comparer
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/illuminarean-labs/yaml-diff-reporter/masker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_comparer_CompareMatrix(t *testing.T) {
+	documents := []domain.Document{
+		{Alias: "dev", Values: map[string]any{"a": 1, "b": "x", "c": true}},
+		{Alias: "qa", Values: map[string]any{"a": 1, "b": "y", "c": true}},
+		{Alias: "prod", Values: map[string]any{"a": 2, "b": "x"}},
+	}
+
+	tests := []struct {
+		name      string
+		reference string
+		want      domain.Matrix
+	}{
+		{
+			name: "다수 값 기준",
+			want: domain.Matrix{
+				Rows: []domain.MatrixRow{
+					{Key: "a", Cells: []domain.MatrixCell{
+						{Entry: domain.NewYAMLEntry(1), Present: true},
+						{Entry: domain.NewYAMLEntry(1), Present: true},
+						{Entry: domain.NewYAMLEntry(2), Present: true, Deviates: true},
+					}},
+					{Key: "b", Cells: []domain.MatrixCell{
+						{Entry: domain.NewYAMLEntry("x"), Present: true},
+						{Entry: domain.NewYAMLEntry("y"), Present: true, Deviates: true},
+						{Entry: domain.NewYAMLEntry("x"), Present: true},
+					}},
+					{Key: "c", Cells: []domain.MatrixCell{
+						{Entry: domain.NewYAMLEntry(true), Present: true},
+						{Entry: domain.NewYAMLEntry(true), Present: true},
+						{Deviates: true},
+					}},
+				},
+			},
+		},
+		{
+			name:      "기준 환경 지정",
+			reference: "prod",
+			want: domain.Matrix{
+				Reference: "prod",
+				Rows: []domain.MatrixRow{
+					{Key: "a", Cells: []domain.MatrixCell{
+						{Entry: domain.NewYAMLEntry(1), Present: true, Deviates: true},
+						{Entry: domain.NewYAMLEntry(1), Present: true, Deviates: true},
+						{Entry: domain.NewYAMLEntry(2), Present: true},
+					}},
+					{Key: "b", Cells: []domain.MatrixCell{
+						{Entry: domain.NewYAMLEntry("x"), Present: true},
+						{Entry: domain.NewYAMLEntry("y"), Present: true, Deviates: true},
+						{Entry: domain.NewYAMLEntry("x"), Present: true},
+					}},
+					{Key: "c", Cells: []domain.MatrixCell{
+						{Entry: domain.NewYAMLEntry(true), Present: true, Deviates: true},
+						{Entry: domain.NewYAMLEntry(true), Present: true, Deviates: true},
+						{},
+					}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(Config{Modes: domain.CompareModes{Type, Key, Index, Value}})
+			assert.Equal(t, tt.want, c.CompareMatrix(documents, tt.reference))
+		})
+	}
+}
+
+func Test_comparer_CompareMatrix_KeyStrategy(t *testing.T) {
+	extra := map[string]any{"name": "extra"}
+	documents := []domain.Document{
+		{Alias: "dev", Values: map[string]any{"containers": []any{
+			map[string]any{"name": "app", "image": "app:1"},
+			map[string]any{"name": "side", "image": "side:1"},
+		}}},
+		{Alias: "qa", Values: map[string]any{"containers": []any{
+			map[string]any{"name": "side", "image": "side:1"},
+			map[string]any{"name": "app", "image": "app:2"},
+			extra,
+		}}},
+		{Alias: "prod", Values: map[string]any{"containers": []any{
+			extra,
+			map[string]any{"name": "app", "image": "app:1"},
+			map[string]any{"name": "side", "image": "side:1"},
+		}}},
+	}
+
+	c := New(Config{
+		Modes: domain.CompareModes{Type, Key, Index, Value},
+		Rules: domain.Rules{
+			{Paths: []string{"containers"}, ArrayStrategy: KeyStrategy, ArrayKey: "name"},
+		},
+	})
+	want := domain.Matrix{
+		Reference: "dev",
+		Rows: []domain.MatrixRow{
+			{Key: "containers[0].image", Cells: []domain.MatrixCell{
+				{Entry: domain.NewYAMLEntry("app:1"), Present: true},
+				{Entry: domain.NewYAMLEntry("app:2"), Present: true, Deviates: true},
+				{Entry: domain.NewYAMLEntry("app:1"), Present: true},
+			}},
+			{Key: "containers[2]", Cells: []domain.MatrixCell{
+				{},
+				{Entry: domain.NewYAMLEntry(extra), Present: true, Deviates: true},
+				{Entry: domain.NewYAMLEntry(extra), Present: true, Deviates: true},
+			}},
+		},
+	}
+	assert.Equal(t, want, c.CompareMatrix(documents, "dev"))
+}
+
+func Test_comparer_CompareMatrix_Masker(t *testing.T) {
+	documents := []domain.Document{
+		{Alias: "dev", Values: map[string]any{"password": "hunter2", "image": "app:1"}},
+		{Alias: "qa", Values: map[string]any{"password": "hunter3", "image": "app:1"}},
+		{Alias: "prod", Values: map[string]any{"password": "hunter2", "image": "app:1"}},
+	}
+
+	c := New(Config{
+		Modes:  domain.CompareModes{Type, Key, Index, Value},
+		Masker: masker.New(masker.Config{Style: masker.Redact}),
+	})
+	redacted := domain.NewYAMLEntry(domain.MaskedValue{Type: "string", Display: "[REDACTED]"})
+	assert.Equal(t, domain.Matrix{
+		Rows: []domain.MatrixRow{
+			{Key: "password", Cells: []domain.MatrixCell{
+				{Entry: redacted, Present: true},
+				{Entry: redacted, Present: true, Deviates: true},
+				{Entry: redacted, Present: true},
+			}},
+		},
+	}, c.CompareMatrix(documents, ""))
+}
@@ -0,0 +1,123 @@
+package comparer
+
+import (
+	"sort"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/samber/lo"
+)
+
+const (
+	IndexStrategy     domain.ArrayStrategy = "index"
+	UnorderedStrategy domain.ArrayStrategy = "unordered"
+	KeyStrategy       domain.ArrayStrategy = "key"
+)
+
+// policy 는 한 경로에 적용되는 비교 정책입니다.
+type policy struct {
+	ignore        bool
+	modes         domain.CompareModes
+	arrayStrategy domain.ArrayStrategy
+	arrayKey      string
+	tolerance     float64
+	expected      *domain.ExpectedValue
+	severity      domain.Severity
+}
+
+func (p policy) hasMode(mode domain.CompareMode) bool {
+	return lo.Contains(p.modes, mode)
+}
+
+type compiledRule struct {
+	rule     domain.Rule
+	patterns []domain.PathPattern
+	score    int
+}
+
+// compileRules 는 규칙을 가장 구체적인 것부터 평가되도록 정렬합니다.
+func compileRules(rules domain.Rules) []compiledRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		cr := compiledRule{rule: rule, score: -1}
+		for _, path := range rule.Paths {
+			pattern := domain.NewPathPattern(path)
+			cr.patterns = append(cr.patterns, pattern)
+			cr.score = max(cr.score, pattern.Specificity())
+		}
+		compiled = append(compiled, cr)
+	}
+
+	sort.SliceStable(compiled, func(i, j int) bool {
+		return compiled[i].score > compiled[j].score
+	})
+
+	return compiled
+}
+
+func (cr compiledRule) matches(path []string) bool {
+	for _, pattern := range cr.patterns {
+		if pattern.MatchSegments(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// policy 는 key 에 일치하는 규칙을 구체적인 순서대로 적용해 비교 정책을 결정합니다.
+// 각 항목은 해당 항목을 지정한 가장 구체적인 규칙의 값을 따릅니다.
+func (c comparer) policy(key string) policy {
+	result := policy{
+		ignore:        lo.Contains(c.config.IgnoredKeys, key),
+		modes:         c.config.Modes,
+		arrayStrategy: IndexStrategy,
+	}
+	if len(c.rules) == 0 {
+		return result
+	}
+
+	var (
+		modesSet     bool
+		strategySet  bool
+		toleranceSet bool
+		ignoreSet    bool
+	)
+
+	path := domain.SplitPath(key)
+	for _, cr := range c.rules {
+		if !cr.matches(path) {
+			continue
+		}
+
+		rule := cr.rule
+		if !ignoreSet && rule.Ignore != nil {
+			result.ignore = result.ignore || *rule.Ignore
+			ignoreSet = true
+		}
+		if !modesSet && rule.Modes != nil {
+			result.modes = rule.Modes
+			modesSet = true
+		}
+		if !strategySet && rule.ArrayStrategy != "" {
+			result.arrayStrategy = rule.ArrayStrategy
+			result.arrayKey = rule.ArrayKey
+			strategySet = true
+		}
+		if !toleranceSet && rule.Tolerance != nil {
+			result.tolerance = *rule.Tolerance
+			toleranceSet = true
+		}
+		if result.expected == nil && rule.Expected != nil {
+			result.expected = rule.Expected
+		}
+		if result.severity == "" && rule.Severity != "" {
+			result.severity = rule.Severity
+		}
+	}
+
+	return result
+}
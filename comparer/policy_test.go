@@ -0,0 +1,122 @@
+package comparer
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_comparer_policy(t *testing.T) {
+	c := New(Config{
+		Modes: domain.CompareModes{Type, Key, Index, Value},
+		Rules: domain.Rules{
+			{Paths: []string{"**"}, Modes: domain.CompareModes{Type, Value}},
+			{Paths: []string{"database.**"}, Ignore: lo.ToPtr(true)},
+			{Paths: []string{"database.host"}, Ignore: lo.ToPtr(false)},
+			{Paths: []string{"servers"}, ArrayStrategy: KeyStrategy, ArrayKey: "name"},
+		},
+	}).(comparer)
+
+	tests := []struct {
+		name string
+		key  string
+		want policy
+	}{
+		{
+			name: "가장 구체적인 규칙이 우선",
+			key:  "database.host",
+			want: policy{modes: domain.CompareModes{Type, Value}, arrayStrategy: IndexStrategy},
+		},
+		{
+			name: "와일드카드 규칙 적용",
+			key:  "database.port",
+			want: policy{ignore: true, modes: domain.CompareModes{Type, Value}, arrayStrategy: IndexStrategy},
+		},
+		{
+			name: "배열 전략 적용",
+			key:  "servers",
+			want: policy{modes: domain.CompareModes{Type, Value}, arrayStrategy: KeyStrategy, arrayKey: "name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.policy(tt.key)
+			assert.Equalf(t, tt.want, got, "policy() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func Test_comparer_Compare_rules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules domain.Rules
+		lhs   any
+		rhs   any
+		want  domain.ErrorResults
+	}{
+		{
+			name:  "허용된 값 쌍은 보고하지 않음",
+			rules: domain.Rules{{Paths: []string{"replicas"}, Expected: &domain.ExpectedValue{LHS: 1, RHS: 3}}},
+			lhs:   map[string]any{"replicas": 1},
+			rhs:   map[string]any{"replicas": 3},
+			want:  domain.ErrorResults{},
+		},
+		{
+			name:  "허용 오차 이내의 숫자는 보고하지 않음",
+			rules: domain.Rules{{Paths: []string{"cpu"}, Tolerance: lo.ToPtr(0.5)}},
+			lhs:   map[string]any{"cpu": 1},
+			rhs:   map[string]any{"cpu": 1.5},
+			want:  domain.ErrorResults{},
+		},
+		{
+			name:  "규칙으로 심각도 지정",
+			rules: domain.Rules{{Paths: []string{"database.*"}, Severity: domain.SeverityCritical}},
+			lhs:   map[string]any{"database": map[string]any{"url": "a"}},
+			rhs:   map[string]any{"database": map[string]any{"url": "b"}},
+			want: domain.ErrorResults{
+				{
+					Key:       "database.url",
+					LHS:       domain.NewYAMLEntry("a"),
+					RHS:       domain.NewYAMLEntry("b"),
+					ErrorCode: domain.ErrorValueUnmatched,
+					Severity:  domain.SeverityCritical,
+				},
+			},
+		},
+		{
+			name:  "순서와 무관한 배열 비교",
+			rules: domain.Rules{{Paths: []string{"hosts"}, ArrayStrategy: UnorderedStrategy}},
+			lhs:   map[string]any{"hosts": []any{"a", "b"}},
+			rhs:   map[string]any{"hosts": []any{"c", "a"}},
+			want: domain.ErrorResults{
+				domain.IndexNotFoundResult("hosts[1]", "b", nil),
+				domain.IndexNotFoundResult("hosts[0]", nil, "c"),
+			},
+		},
+		{
+			name:  "키 기준 배열 비교",
+			rules: domain.Rules{{Paths: []string{"servers"}, ArrayStrategy: KeyStrategy, ArrayKey: "name"}},
+			lhs: map[string]any{"servers": []any{
+				map[string]any{"name": "a", "port": 1},
+				map[string]any{"name": "b", "port": 2},
+			}},
+			rhs: map[string]any{"servers": []any{
+				map[string]any{"name": "b", "port": 3},
+				map[string]any{"name": "a", "port": 1},
+			}},
+			want: domain.ErrorResults{
+				domain.ValueUnmatchedResult("servers[1].port", 2, 3),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(Config{Modes: domain.CompareModes{Type, Key, Index, Value}, Rules: tt.rules})
+			c.Compare("", tt.lhs, tt.rhs)
+			assert.Equal(t, tt.want, *c.Results())
+		})
+	}
+}
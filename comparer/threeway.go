@@ -0,0 +1,133 @@
+package comparer
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+// CompareThreeWay 는 base 를 기준으로 lhs, rhs 각각의 변경을 Compare 와 같은 방식으로 찾은 뒤,
+// 각 경로를 한쪽에서만 변경, 양쪽에서 동일하게 변경, 충돌로 분류합니다.
+// 한쪽의 변경 경로가 다른 쪽 변경 경로의 상위 또는 하위 경로인 경우 상위 경로의 충돌로 보고합니다.
+func (c comparer) CompareThreeWay(parent string, base any, lhs any, rhs any) {
+	lhsChanges := c.changedKeys(parent, base, lhs)
+	rhsChanges := c.changedKeys(parent, base, rhs)
+
+	conflicts := make(map[string]bool)
+	for _, lhsKey := range lhsChanges {
+		for _, rhsKey := range rhsChanges {
+			if lhsKey == rhsKey {
+				lhsVal, lhsOk := domain.Lookup(lhs, lhsKey)
+				rhsVal, rhsOk := domain.Lookup(rhs, rhsKey)
+				if lhsOk != rhsOk || !reflect.DeepEqual(lhsVal, rhsVal) {
+					conflicts[lhsKey] = true
+				}
+				continue
+			}
+
+			if isDescendant(rhsKey, lhsKey) {
+				conflicts[lhsKey] = true
+			} else if isDescendant(lhsKey, rhsKey) {
+				conflicts[rhsKey] = true
+			}
+		}
+	}
+
+	reported := make(map[string]bool)
+	report := func(key string, code domain.ErrorCode) {
+		if reported[key] || hasReportedAncestor(reported, key) {
+			return
+		}
+		reported[key] = true
+
+		baseVal, _ := domain.Lookup(base, key)
+		lhsVal, _ := domain.Lookup(lhs, key)
+		rhsVal, _ := domain.Lookup(rhs, key)
+		c.addResult(c.policy(key), domain.ThreeWayResult(key, code, c.display(key, baseVal), c.display(key, lhsVal), c.display(key, rhsVal)))
+	}
+
+	for _, key := range sortedKeys(conflicts) {
+		report(key, domain.ErrorThreeWayConflict)
+	}
+
+	rhsSet := make(map[string]bool, len(rhsChanges))
+	for _, key := range rhsChanges {
+		rhsSet[key] = true
+	}
+	lhsSet := make(map[string]bool, len(lhsChanges))
+	for _, key := range lhsChanges {
+		lhsSet[key] = true
+		if rhsSet[key] {
+			report(key, domain.ErrorChangedIdentically)
+		} else {
+			report(key, domain.ErrorChangedInLHS)
+		}
+	}
+	for _, key := range rhsChanges {
+		if !lhsSet[key] {
+			report(key, domain.ErrorChangedInRHS)
+		}
+	}
+}
+
+// changedKeys 는 base 와 target 을 Compare 로 비교해 차이가 발견된 경로를 반환합니다.
+// 방문 통계는 c 에 합산되므로 3-way 비교의 통계는 base 와 lhs, base 와 rhs 두 번의 비교를 모두 포함합니다.
+func (c comparer) changedKeys(parent string, base any, target any) []string {
+	results := c.changedResults(parent, base, target)
+
+	keys := make([]string, 0, len(results))
+	for _, result := range results {
+		keys = append(keys, result.Key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// changedResults 는 c 의 결과에 영향을 주지 않도록 별도의 결과 목록에 base 와 target 의 비교 결과를 모읍니다.
+func (c comparer) changedResults(parent string, base any, target any) domain.ErrorResults {
+	side := comparer{
+		results: &domain.ErrorResults{},
+		config:  c.config,
+		rules:   c.rules,
+		stats:   c.stats,
+	}
+	side.Compare(parent, base, target)
+
+	return *side.results
+}
+
+func isDescendant(key string, ancestor string) bool {
+	if ancestor == "" {
+		return key != ""
+	}
+
+	if len(key) <= len(ancestor) || !strings.HasPrefix(key, ancestor) {
+		return false
+	}
+
+	next := key[len(ancestor)]
+	return next == '.' || next == '['
+}
+
+func hasReportedAncestor(reported map[string]bool, key string) bool {
+	for ancestor := range reported {
+		if isDescendant(key, ancestor) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,65 @@
+package comparer
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_comparer_CompareThreeWay(t *testing.T) {
+	type args struct {
+		base any
+		lhs  any
+		rhs  any
+	}
+	tests := []struct {
+		name string
+		args args
+		want domain.ErrorResults
+	}{
+		{
+			name: "한쪽에서만 변경",
+			args: args{
+				base: map[string]any{"a": 1, "b": 1},
+				lhs:  map[string]any{"a": 2, "b": 1},
+				rhs:  map[string]any{"a": 1, "b": 1, "c": 1},
+			},
+			want: domain.ErrorResults{
+				domain.ThreeWayResult("a", domain.ErrorChangedInLHS, 1, 2, 1),
+				domain.ThreeWayResult("c", domain.ErrorChangedInRHS, nil, nil, 1),
+			},
+		},
+		{
+			name: "양쪽에서 동일하게 변경",
+			args: args{
+				base: map[string]any{"a": 1},
+				lhs:  map[string]any{"a": 2},
+				rhs:  map[string]any{"a": 2},
+			},
+			want: domain.ErrorResults{
+				domain.ThreeWayResult("a", domain.ErrorChangedIdentically, 1, 2, 2),
+			},
+		},
+		{
+			name: "충돌",
+			args: args{
+				base: map[string]any{"a": 1, "db": map[string]any{"host": "x"}},
+				lhs:  map[string]any{"a": 2, "db": "external"},
+				rhs:  map[string]any{"a": 3, "db": map[string]any{"host": "y"}},
+			},
+			want: domain.ErrorResults{
+				domain.ThreeWayResult("a", domain.ErrorThreeWayConflict, 1, 2, 3),
+				domain.ThreeWayResult("db", domain.ErrorThreeWayConflict, map[string]any{"host": "x"}, "external", map[string]any{"host": "y"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(Config{Modes: domain.CompareModes{Type, Key, Index, Value}})
+			c.CompareThreeWay("", tt.args.base, tt.args.lhs, tt.args.rhs)
+			assert.Equal(t, tt.want, *c.Results())
+		})
+	}
+}
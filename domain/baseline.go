@@ -0,0 +1,53 @@
+package domain
+
+type BaselineEntry struct {
+	Key       string        `yaml:"key"`
+	ErrorCode ErrorCode     `yaml:"errorCode"`
+	LHS       BaselineValue `yaml:"lhs"`
+	RHS       BaselineValue `yaml:"rhs"`
+}
+
+type BaselineValue struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+	// Digest 는 마스킹된 값의 원본 해시입니다. 마스킹된 값은 표시 값 대신 Digest 로 비교합니다.
+	Digest string `yaml:"digest,omitempty"`
+}
+
+type BaselineFile struct {
+	Entries []BaselineEntry `yaml:"entries"`
+}
+
+func NewBaselineEntry(result ErrorResult) BaselineEntry {
+	return BaselineEntry{
+		Key:       result.Key,
+		ErrorCode: result.ErrorCode,
+		LHS:       BaselineValue{Type: result.LHS.Type, Value: result.LHS.Value, Digest: result.LHS.Digest},
+		RHS:       BaselineValue{Type: result.RHS.Type, Value: result.RHS.Value, Digest: result.RHS.Digest},
+	}
+}
+
+// Identity 는 baseline 항목을 짝지을 때 사용하는 값입니다.
+// 마스킹된 값은 --mask-style 에 따라 표시 값이 달라지므로 Digest 로만 비교합니다.
+func (e BaselineEntry) Identity() BaselineEntry {
+	e.LHS, e.RHS = e.LHS.identity(), e.RHS.identity()
+	return e
+}
+
+func (v BaselineValue) identity() BaselineValue {
+	if v.Digest == "" {
+		return v
+	}
+
+	return BaselineValue{Type: v.Type, Digest: v.Digest}
+}
+
+func BaselineStaleResult(entry BaselineEntry) ErrorResult {
+	return ErrorResult{
+		Key:       entry.Key,
+		LHS:       YAMLEntry{Type: entry.LHS.Type, Value: entry.LHS.Value, Digest: entry.LHS.Digest},
+		RHS:       YAMLEntry{Type: entry.RHS.Type, Value: entry.RHS.Value, Digest: entry.RHS.Digest},
+		ErrorCode: ErrorBaselineStale,
+		Severity:  DefaultSeverity(ErrorBaselineStale),
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+// MessageCatalog 는 한 언어의 리포트 메시지 모음입니다.
+// 메시지의 {name} 은 리포트 생성 시 값으로 치환됩니다. ex. {key}, {lhs}, {lhsValue}
+type MessageCatalog struct {
+	Language ReportLanguage             `yaml:"language"`
+	Codes    map[ErrorCode]CodeMessages `yaml:"codes"`
+	Messages map[string]string          `yaml:"messages"`
+}
+
+// CodeMessages 는 에러 코드 하나의 리포트 형식별 메시지입니다.
+//   - Title: markdown 표처럼 값을 따로 출력하는 형식의 짧은 설명
+//   - Description: json, html 등의 설명
+//   - Line: plain 형식의 한 줄 설명
+type CodeMessages struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Line        string `yaml:"line"`
+}
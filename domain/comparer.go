@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 type CompareMode string
@@ -25,6 +29,13 @@ type ErrorResult struct {
 	LHS       YAMLEntry
 	RHS       YAMLEntry
 	ErrorCode ErrorCode
+	Severity  Severity
+	// Base 는 3-way 비교에서 공통 조상의 값입니다.
+	Base YAMLEntry
+	// Message 는 스키마 위반처럼 에러 코드만으로 설명할 수 없는 결과의 상세 내용입니다.
+	Message string
+	// Group 은 결과가 속한 문서(ex. Kubernetes 리소스)의 식별자로, 리포트를 묶어 보여줄 때 사용합니다.
+	Group string
 }
 
 func (er ErrorResult) FindNilSide() string {
@@ -45,12 +56,25 @@ func (er ErrorResults) IsEmpty() bool {
 	return len(er) == 0
 }
 
+// AnnotateOrigins 는 각 결과에 해당 값을 제공한 레이어 파일을 기록합니다.
+func (er ErrorResults) AnnotateOrigins(lhs Origins, rhs Origins) {
+	for i := range er {
+		if er[i].LHS.Type != "null" && er[i].LHS.Type != "" {
+			er[i].LHS.Source = lhs.Lookup(er[i].Key)
+		}
+		if er[i].RHS.Type != "null" && er[i].RHS.Type != "" {
+			er[i].RHS.Source = rhs.Lookup(er[i].Key)
+		}
+	}
+}
+
 func TypeUnmatchedResult(key string, lhs any, rhs any) ErrorResult {
 	return ErrorResult{
 		Key:       key,
 		LHS:       NewYAMLEntry(lhs),
 		RHS:       NewYAMLEntry(rhs),
 		ErrorCode: ErrorTypeUnmatched,
+		Severity:  DefaultSeverity(ErrorTypeUnmatched),
 	}
 }
 
@@ -60,32 +84,134 @@ func ValueUnmatchedResult(key string, lhs any, rhs any) ErrorResult {
 		LHS:       NewYAMLEntry(lhs),
 		RHS:       NewYAMLEntry(rhs),
 		ErrorCode: ErrorValueUnmatched,
+		Severity:  DefaultSeverity(ErrorValueUnmatched),
 	}
 }
 
 func KeyNotFoundResult(key string, lhs any, rhs any) ErrorResult {
 	return ErrorResult{
 		Key:       key,
-		LHS:       NewYAMLEntry(lhs),
-		RHS:       NewYAMLEntry(rhs),
+		LHS:       NewSubtreeEntry(lhs),
+		RHS:       NewSubtreeEntry(rhs),
 		ErrorCode: ErrorKeyNotFound,
+		Severity:  DefaultSeverity(ErrorKeyNotFound),
 	}
 }
 
 func IndexNotFoundResult(key string, lhs any, rhs any) ErrorResult {
+	return ErrorResult{
+		Key:       key,
+		LHS:       NewSubtreeEntry(lhs),
+		RHS:       NewSubtreeEntry(rhs),
+		ErrorCode: ErrorIndexNotFound,
+		Severity:  DefaultSeverity(ErrorIndexNotFound),
+	}
+}
+
+// DefaultEquivalentResult 는 한쪽에만 존재하는 키의 값이 다른 쪽 스키마 기본값과 같은 결과를 생성합니다.
+// KEY_NOT_FOUND 와 같이 키가 없던 쪽의 값은 null 로 기록합니다.
+func DefaultEquivalentResult(key string, lhs any, rhs any) ErrorResult {
 	return ErrorResult{
 		Key:       key,
 		LHS:       NewYAMLEntry(lhs),
 		RHS:       NewYAMLEntry(rhs),
-		ErrorCode: ErrorIndexNotFound,
+		ErrorCode: ErrorDefaultEqual,
+		Severity:  DefaultSeverity(ErrorDefaultEqual),
+	}
+}
+
+// SchemaViolationResult 는 한쪽 문서의 스키마 위반 결과를 생성합니다.
+// 위반이 발생하지 않은 쪽의 값은 비워 둡니다.
+func SchemaViolationResult(key string, side string, value any, message string) ErrorResult {
+	result := ErrorResult{
+		Key:       key,
+		ErrorCode: ErrorSchemaViolated,
+		Severity:  DefaultSeverity(ErrorSchemaViolated),
+		Message:   message,
+	}
+
+	if side == "LHS" {
+		result.LHS = NewYAMLEntry(value)
+	} else {
+		result.RHS = NewYAMLEntry(value)
+	}
+
+	return result
+}
+
+// ViolatedSide 는 스키마 위반이 발생한 쪽을 반환합니다.
+func (er ErrorResult) ViolatedSide() string {
+	if er.LHS.Type != "" {
+		return "LHS"
+	}
+
+	return "RHS"
+}
+
+// Redisplay 는 스키마 위반 결과의 값을 마스킹된 문서의 값으로 교체합니다.
+// 값이 마스킹된 경우 원본 값이 포함될 수 있는 상세 메시지도 함께 제거합니다.
+func (er ErrorResults) Redisplay(lhs map[string]any, rhs map[string]any) {
+	for i := range er {
+		if er[i].ErrorCode != ErrorSchemaViolated {
+			continue
+		}
+
+		document, entry := rhs, &er[i].RHS
+		if er[i].ViolatedSide() == "LHS" {
+			document, entry = lhs, &er[i].LHS
+		}
+
+		value, _ := Lookup(document, er[i].Key)
+		*entry = NewYAMLEntry(value)
+		if _, masked := value.(MaskedValue); masked {
+			er[i].Message = ""
+		}
+	}
+}
+
+func ThreeWayResult(key string, code ErrorCode, base any, lhs any, rhs any) ErrorResult {
+	return ErrorResult{
+		Key:       key,
+		Base:      NewYAMLEntry(base),
+		LHS:       NewYAMLEntry(lhs),
+		RHS:       NewYAMLEntry(rhs),
+		ErrorCode: code,
+		Severity:  DefaultSeverity(code),
+	}
+}
+
+// IsThreeWay 는 3-way 비교 결과인지 확인합니다.
+func (er ErrorResult) IsThreeWay() bool {
+	switch er.ErrorCode {
+	case ErrorChangedInLHS, ErrorChangedInRHS, ErrorChangedIdentically, ErrorThreeWayConflict:
+		return true
+	default:
+		return false
 	}
 }
 
+// ClassifiedResult 는 비교 결과를 OpenAPI 호환성 분류(BREAKING_CHANGE, NON_BREAKING_CHANGE) 결과로 바꿉니다.
+// 키와 양쪽 값, 출처는 그대로 유지하고 분류 사유를 Message 에 기록합니다.
+func ClassifiedResult(result ErrorResult, code ErrorCode, message string) ErrorResult {
+	result.ErrorCode = code
+	result.Severity = DefaultSeverity(code)
+	result.Message = message
+
+	return result
+}
+
 type Results []ErrorResult
 
 type YAMLEntry struct {
-	Type  string
-	Value string
+	Type   string
+	Value  string
+	Source string
+	// Digest 는 마스킹된 값(또는 마스킹된 값을 포함한 map, array)의 원본 해시입니다.
+	Digest string
+	// Subtree 는 한쪽에만 존재하는 map, array 의 하위 트리 전체입니다. 마스킹된 값은 표시용 문자열로 바뀌어 있습니다.
+	Subtree any
+	// KeyCount 는 Subtree 에 포함된 값(leaf)의 개수입니다.
+	KeyCount int
 }
 
 func NewYAMLEntry(entry any) YAMLEntry {
@@ -96,6 +222,14 @@ func NewYAMLEntry(entry any) YAMLEntry {
 		}
 	}
 
+	if masked, ok := entry.(MaskedValue); ok {
+		return YAMLEntry{
+			Type:   masked.Type,
+			Value:  masked.Display,
+			Digest: masked.Digest,
+		}
+	}
+
 	var typeString string
 	switch reflect.TypeOf(entry).Kind() {
 	case reflect.Array, reflect.Slice:
@@ -117,7 +251,95 @@ func NewYAMLEntry(entry any) YAMLEntry {
 	}
 
 	return YAMLEntry{
-		Type:  typeString,
-		Value: fmt.Sprintf("%v", entry),
+		Type:   typeString,
+		Value:  fmt.Sprintf("%v", entry),
+		Digest: maskedDigest(entry),
+	}
+}
+
+// maskedDigest 는 map, array 에 포함된 마스킹된 값의 해시를 경로 순서대로 모아 다시 해시합니다.
+// 마스킹된 값이 없는 경우 빈 문자열을 반환합니다.
+func maskedDigest(entry any) string {
+	var digests []string
+	collectDigests("", entry, &digests)
+	if len(digests) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(digests, ",")))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+func collectDigests(key string, value any, digests *[]string) {
+	switch value := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for childKey := range value {
+			keys = append(keys, childKey)
+		}
+		sort.Strings(keys)
+
+		for _, childKey := range keys {
+			collectDigests(MapKey(key, childKey), value[childKey], digests)
+		}
+	case []any:
+		for idx, elem := range value {
+			collectDigests(SliceKey(key, idx), elem, digests)
+		}
+	case MaskedValue:
+		if value.Digest != "" {
+			*digests = append(*digests, key+"="+value.Digest)
+		}
+	}
+}
+
+// NewSubtreeEntry 는 한쪽에만 존재하는 값의 YAMLEntry 를 생성합니다.
+// 값이 map, array 인 경우 하위 트리 전체를 하나의 결과로 표시할 수 있도록 Subtree 와 KeyCount 를 함께 기록합니다.
+func NewSubtreeEntry(entry any) YAMLEntry {
+	result := NewYAMLEntry(entry)
+
+	switch entry.(type) {
+	case map[string]any, []any:
+		result.Subtree, result.KeyCount = displaySubtree(entry)
+	}
+
+	return result
+}
+
+// displaySubtree 는 마스킹된 값을 표시용 문자열로 바꾼 하위 트리와 leaf 값의 개수를 반환합니다.
+func displaySubtree(value any) (any, int) {
+	switch value := value.(type) {
+	case map[string]any:
+		subtree, count := make(map[string]any, len(value)), 0
+		for key, child := range value {
+			var n int
+			subtree[key], n = displaySubtree(child)
+			count += n
+		}
+		return subtree, count
+	case []any:
+		subtree, count := make([]any, len(value)), 0
+		for idx, child := range value {
+			var n int
+			subtree[idx], n = displaySubtree(child)
+			count += n
+		}
+		return subtree, count
+	case MaskedValue:
+		return value.Display, 1
+	default:
+		return value, 1
+	}
+}
+
+// SubtreeEntry 는 하위 트리 전체가 한쪽에만 존재하는 결과인 경우 그 쪽의 값을 반환합니다.
+func (er ErrorResult) SubtreeEntry() (YAMLEntry, bool) {
+	switch {
+	case er.LHS.Subtree != nil:
+		return er.LHS, true
+	case er.RHS.Subtree != nil:
+		return er.RHS, true
+	default:
+		return YAMLEntry{}, false
 	}
 }
@@ -5,4 +5,15 @@ const (
 	ErrorIndexNotFound  ErrorCode = "INDEX_NOT_FOUND"
 	ErrorTypeUnmatched  ErrorCode = "TYPE_UNMATCHED"
 	ErrorValueUnmatched ErrorCode = "VALUE_UNMATCHED"
+	ErrorBaselineStale  ErrorCode = "BASELINE_STALE"
+	ErrorSchemaViolated ErrorCode = "SCHEMA_VIOLATION"
+	ErrorDefaultEqual   ErrorCode = "DEFAULT_EQUIVALENT"
+
+	ErrorChangedInLHS       ErrorCode = "CHANGED_IN_LHS"
+	ErrorChangedInRHS       ErrorCode = "CHANGED_IN_RHS"
+	ErrorChangedIdentically ErrorCode = "CHANGED_IDENTICALLY"
+	ErrorThreeWayConflict   ErrorCode = "CONFLICT"
+
+	ErrorBreakingChange    ErrorCode = "BREAKING_CHANGE"
+	ErrorNonBreakingChange ErrorCode = "NON_BREAKING_CHANGE"
 )
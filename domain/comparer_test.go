@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSubtreeEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry any
+		want  YAMLEntry
+	}{
+		{
+			name:  "스칼라 값",
+			entry: "hello",
+			want:  YAMLEntry{Type: "string", Value: "hello"},
+		},
+		{
+			name: "중첩된 맵과 배열",
+			entry: map[string]any{
+				"beta":  true,
+				"zones": []any{"a", "b"},
+				"limits": map[string]any{
+					"cpu": 1,
+				},
+			},
+			want: YAMLEntry{
+				Type:  "map",
+				Value: "map[beta:true limits:map[cpu:1] zones:[a b]]",
+				Subtree: map[string]any{
+					"beta":  true,
+					"zones": []any{"a", "b"},
+					"limits": map[string]any{
+						"cpu": 1,
+					},
+				},
+				KeyCount: 4,
+			},
+		},
+		{
+			name:  "마스킹된 값",
+			entry: []any{MaskedValue{Type: "string", Display: "[MASKED]", Digest: "abc"}},
+			want: YAMLEntry{
+				Type:     "array",
+				Value:    "[[MASKED]]",
+				Digest:   "c4b46b43",
+				Subtree:  []any{"[MASKED]"},
+				KeyCount: 1,
+			},
+		},
+		{
+			name:  "Digest 가 없는 마스킹된 값",
+			entry: []any{MaskedValue{Type: "string", Display: "[MASKED]"}},
+			want: YAMLEntry{
+				Type:     "array",
+				Value:    "[[MASKED]]",
+				Subtree:  []any{"[MASKED]"},
+				KeyCount: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewSubtreeEntry(tt.entry))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+type MaskStyle string
+
+// MaskedValue 는 리포트에 원본 값 대신 표시되는 마스킹된 값입니다.
+// Digest 는 원본 값의 HMAC 으로, 마스킹된 값끼리도 변경 여부를 비교할 수 있습니다. HMAC 키가 없으면 비어 있습니다.
+type MaskedValue struct {
+	Type    string
+	Display string
+	Digest  string
+}
+
+func (m MaskedValue) String() string {
+	return m.Display
+}
@@ -0,0 +1,32 @@
+package domain
+
+// Document 는 N-way 비교에 사용되는 하나의 입력 문서입니다.
+type Document struct {
+	Alias     string
+	Path      string
+	Values    map[string]any
+	Encrypted []string
+}
+
+// MatrixCell 은 한 환경에서의 값과 다수/기준 값과의 차이 여부입니다.
+type MatrixCell struct {
+	Entry    YAMLEntry
+	Present  bool
+	Deviates bool
+}
+
+type MatrixRow struct {
+	Key   string
+	Cells []MatrixCell
+}
+
+// Matrix 는 N개 환경 중 어느 한 곳에서라도 다른 경로를 환경별 값과 함께 나타냅니다.
+// Reference 가 비어 있는 경우 각 경로에서 가장 많은 환경이 가진 값과 다른 환경을 표시합니다.
+type Matrix struct {
+	Reference string
+	Rows      []MatrixRow
+}
+
+func (m Matrix) IsEmpty() bool {
+	return len(m.Rows) == 0
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "gopkg.in/yaml.v3"
+
+type ConflictStyle string
+
+// MergeResult 는 3-way 병합 결과입니다.
+// 키 순서와 주석을 유지하기 위해 문서는 yaml.Node 로 다룹니다.
+type MergeResult struct {
+	Merged    *yaml.Node
+	LHS       *yaml.Node
+	RHS       *yaml.Node
+	Conflicts ErrorResults
+}
+
+func (mr MergeResult) HasConflicts() bool {
+	return !mr.Conflicts.IsEmpty()
+}
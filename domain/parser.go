@@ -1,6 +1,38 @@
 package domain
 
+import "strings"
+
 type ParserResult struct {
 	LHS map[string]any
 	RHS map[string]any
+	// Base 는 3-way 비교의 공통 조상 문서입니다. base 경로가 지정되지 않은 경우 nil 입니다.
+	Base map[string]any
+
+	LHSOrigins Origins
+	RHSOrigins Origins
+
+	// LHSEncrypted, RHSEncrypted 는 SOPS 로 암호화되어 있던 값의 경로입니다.
+	LHSEncrypted  []string
+	RHSEncrypted  []string
+	BaseEncrypted []string
+}
+
+// Origins 는 병합된 문서의 각 경로가 어떤 레이어 파일에서 왔는지 기록합니다.
+type Origins map[string]string
+
+// Lookup 은 key 또는 가장 가까운 상위 경로의 출처 파일을 반환합니다.
+func (o Origins) Lookup(key string) string {
+	for key != "" {
+		if source, ok := o[key]; ok {
+			return source
+		}
+
+		idx := strings.LastIndexAny(key, ".[")
+		if idx < 0 {
+			return ""
+		}
+		key = key[:idx]
+	}
+
+	return ""
 }
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrigins_Lookup(t *testing.T) {
+	origins := Origins{"hosts": "prod.yaml", "db.host": "base.yaml"}
+
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "배열 요소는 배열의 출처", key: "hosts[1]", want: "prod.yaml"},
+		{name: "기록된 경로", key: "db.host", want: "base.yaml"},
+		{name: "기록되지 않은 경로", key: "db.port", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, origins.Lookup(tt.key))
+		})
+	}
+}
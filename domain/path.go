@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	anySegment  = "*"
+	anyIndex    = "[*]"
+	anySegments = "**"
+)
+
+func MapKey(parent string, key string) string {
+	if parent == "" {
+		return key
+	}
+
+	return fmt.Sprintf("%s.%s", parent, key)
+}
+
+func SliceKey(parent string, index int) string {
+	return fmt.Sprintf("%s[%d]", parent, index)
+}
+
+// SplitPath 는 "a.b[0].c" 형식의 경로를 ["a", "b", "[0]", "c"] 로 분리합니다.
+func SplitPath(path string) []string {
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		for part != "" {
+			idx := strings.Index(part[1:], "[")
+			if idx < 0 {
+				segments = append(segments, part)
+				break
+			}
+			segments = append(segments, part[:idx+1])
+			part = part[idx+1:]
+		}
+	}
+
+	return segments
+}
+
+// PathPattern 은 `*`(맵 키 한 단계), `[*]`(인덱스 한 단계), `**`(0개 이상의 단계)를 지원하는 경로 패턴입니다.
+type PathPattern []string
+
+func NewPathPattern(pattern string) PathPattern {
+	return SplitPath(pattern)
+}
+
+func (p PathPattern) Match(key string) bool {
+	return p.MatchSegments(SplitPath(key))
+}
+
+func (p PathPattern) MatchSegments(path []string) bool {
+	if len(p) == 0 {
+		return len(path) == 0
+	}
+
+	if p[0] == anySegments {
+		for i := 0; i <= len(path); i++ {
+			if p[1:].MatchSegments(path[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(path) == 0 || !matchSegment(p[0], path[0]) {
+		return false
+	}
+
+	return p[1:].MatchSegments(path[1:])
+}
+
+// Specificity 는 고정 세그먼트가 많을수록, 와일드카드가 적을수록 높은 점수를 반환합니다.
+func (p PathPattern) Specificity() int {
+	score := 0
+	recursive := false
+	for _, segment := range p {
+		switch segment {
+		case anySegments:
+			recursive = true
+		case anySegment, anyIndex:
+			score += 1
+		default:
+			score += 4
+		}
+	}
+
+	if !recursive {
+		score += 2
+	}
+
+	return score
+}
+
+func matchSegment(pattern string, segment string) bool {
+	isIndex := strings.HasPrefix(segment, "[")
+	switch pattern {
+	case anySegment:
+		return !isIndex
+	case anyIndex:
+		return isIndex
+	default:
+		return pattern == segment
+	}
+}
+
+// Lookup 은 "a.b[0]" 형식의 경로에 해당하는 값을 찾습니다.
+// 키에 "." 이 포함된 맵 키(ex. "kubernetes.io/name")도 찾을 수 있도록 연속한 세그먼트를 이어서 시도합니다.
+func Lookup(document any, key string) (any, bool) {
+	return lookupSegments(document, SplitPath(key))
+}
+
+func lookupSegments(current any, segments []string) (any, bool) {
+	if len(segments) == 0 {
+		return current, true
+	}
+
+	segment := segments[0]
+	if strings.HasPrefix(segment, "[") {
+		list, ok := current.([]any)
+		if !ok {
+			return nil, false
+		}
+
+		idx, err := strconv.Atoi(strings.Trim(segment, "[]"))
+		if err != nil || idx < 0 || idx >= len(list) {
+			return nil, false
+		}
+
+		return lookupSegments(list[idx], segments[1:])
+	}
+
+	m, ok := current.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	for end := 1; end <= len(segments); end++ {
+		if end > 1 && strings.HasPrefix(segments[end-1], "[") {
+			break
+		}
+
+		value, ok := m[strings.Join(segments[:end], ".")]
+		if !ok {
+			continue
+		}
+
+		if result, ok := lookupSegments(value, segments[end:]); ok {
+			return result, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "맵 키와 인덱스",
+			path: "hello.world[0][1].name",
+			want: []string{"hello", "world", "[0]", "[1]", "name"},
+		},
+		{
+			name: "와일드카드",
+			path: "**.containers[*].image",
+			want: []string{"**", "containers", "[*]", "image"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitPath(tt.path)
+			assert.Equalf(t, tt.want, got, "SplitPath() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestPathPattern_Match(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		key     string
+		want    bool
+	}{
+		{name: "고정 경로", pattern: "a.b", key: "a.b", want: true},
+		{name: "한 단계 와일드카드", pattern: "a.*.c", key: "a.b.c", want: true},
+		{name: "한 단계 와일드카드는 인덱스와 일치하지 않음", pattern: "a.*", key: "a[0]", want: false},
+		{name: "인덱스 와일드카드", pattern: "a[*].name", key: "a[3].name", want: true},
+		{name: "재귀 와일드카드는 0단계와 일치", pattern: "a.**", key: "a", want: true},
+		{name: "재귀 와일드카드", pattern: "**.password", key: "db[0].auth.password", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPathPattern(tt.pattern).Match(tt.key)
+			assert.Equalf(t, tt.want, got, "Match() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	document := map[string]any{"a": []any{map[string]any{"b": 1}}}
+
+	got, ok := Lookup(document, "a[0].b")
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+
+	_, ok = Lookup(document, "a[1].b")
+	assert.False(t, ok)
+
+	dotted := map[string]any{"apps/v1/Deployment/web.example": map[string]any{"metadata": map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "web"}}}}
+	got, ok = Lookup(dotted, "apps/v1/Deployment/web.example.metadata.labels.app.kubernetes.io/name")
+	assert.True(t, ok)
+	assert.Equal(t, "web", got)
+}
@@ -0,0 +1,35 @@
+package domain
+
+// Profile 은 특정 형식의 문서를 비교할 때 필요한 설정 묶음입니다.
+// 모든 경로 패턴은 각 문서의 루트를 기준으로 합니다.
+type Profile struct {
+	Name string `yaml:"name"`
+	// Extends 는 설정을 이어받을 내장 프로파일 이름입니다.
+	Extends string `yaml:"extends"`
+	// DocumentKey 는 여러 문서로 구성된 파일에서 문서를 식별하는 필드 경로입니다.
+	// 지정된 경우 각 문서는 필드 값을 "/" 로 이은 식별자를 키로 하여 짝지어 비교됩니다.
+	DocumentKey []string `yaml:"documentKey"`
+	// Ignore 는 비교 전에 각 문서에서 제거할 경로 패턴입니다.
+	Ignore []string `yaml:"ignore"`
+	// ArrayKeys 는 배열 요소를 식별하는 키입니다.
+	ArrayKeys []ArrayKey `yaml:"arrayKeys"`
+	// Normalizers 는 비교 전에 같은 의미의 다른 표기를 하나로 맞추는 변환입니다.
+	Normalizers []Normalizer `yaml:"normalizers"`
+}
+
+// Based 는 name 프로파일이거나 name 내장 프로파일을 확장한 프로파일인지 확인합니다.
+func (p Profile) Based(name string) bool {
+	return p.Name == name || p.Extends == name
+}
+
+type ArrayKey struct {
+	Path string `yaml:"path"`
+	Key  string `yaml:"key"`
+}
+
+type NormalizerType string
+
+type Normalizer struct {
+	Path string         `yaml:"path"`
+	Type NormalizerType `yaml:"type"`
+}
@@ -6,12 +6,55 @@ type ReportLanguage string
 
 type ReportOutputType string
 
+// DiffView 는 terminal 형식에서 두 문서를 나란히(side-by-side) 또는 한 열(unified)로 표시하는 방식입니다.
+type DiffView string
+
+// GroupBy 는 리포트에서 결과를 묶는 기준입니다.
+type GroupBy string
+
+// SortBy 는 리포트에서 결과를 정렬하는 기준입니다.
+type SortBy string
+
 type Report struct {
 	Key         string    `json:"key"`
 	ErrorCode   ErrorCode `json:"errorCode"`
+	Severity    Severity  `json:"severity"`
 	Description string    `json:"description"`
+	LHSSource   string    `json:"lhsSource,omitempty"`
+	RHSSource   string    `json:"rhsSource,omitempty"`
+	Group       string    `json:"group,omitempty"`
+	Subtree     any       `json:"subtree,omitempty"`
+	KeyCount    int       `json:"keyCount,omitempty"`
+}
+
+// ReportGroup 은 --group-by 로 묶은 결과입니다. 하위 묶음을 가질 수 있습니다.
+type ReportGroup struct {
+	Name    string        `json:"name"`
+	Reports []Report      `json:"reports,omitempty"`
+	Groups  []ReportGroup `json:"groups,omitempty"`
 }
 
 type ReportResponse struct {
-	Reports []Report `json:"reports"`
+	Summary Summary       `json:"summary"`
+	Reports []Report      `json:"reports,omitempty"`
+	Groups  []ReportGroup `json:"groups,omitempty"`
+}
+
+type MatrixValueReport struct {
+	Alias    string `json:"alias"`
+	Type     string `json:"type,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Present  bool   `json:"present"`
+	Deviates bool   `json:"deviates"`
+}
+
+type MatrixRowReport struct {
+	Key    string              `json:"key"`
+	Values []MatrixValueReport `json:"values"`
+}
+
+type MatrixReportResponse struct {
+	Aliases   []string          `json:"aliases"`
+	Reference string            `json:"reference,omitempty"`
+	Rows      []MatrixRowReport `json:"rows"`
 }
@@ -0,0 +1,26 @@
+package domain
+
+type ArrayStrategy string
+
+type Rule struct {
+	Paths         []string       `yaml:"paths"`
+	Modes         []CompareMode  `yaml:"modes"`
+	ArrayStrategy ArrayStrategy  `yaml:"arrayStrategy"`
+	ArrayKey      string         `yaml:"arrayKey"`
+	Tolerance     *float64       `yaml:"tolerance"`
+	Ignore        *bool          `yaml:"ignore"`
+	Expected      *ExpectedValue `yaml:"expected"`
+	Severity      Severity       `yaml:"severity"`
+}
+
+// ExpectedValue 는 두 환경에서 허용되는 값의 쌍입니다.
+type ExpectedValue struct {
+	LHS any `yaml:"lhs"`
+	RHS any `yaml:"rhs"`
+}
+
+type Rules []Rule
+
+type RuleFile struct {
+	Rules Rules `yaml:"rules"`
+}
@@ -0,0 +1,4 @@
+package domain
+
+// DefaultsMode 는 스키마 기본값으로 채워져 같아진 키를 어떻게 다룰지 나타냅니다.
+type DefaultsMode string
@@ -0,0 +1,70 @@
+package domain
+
+import "fmt"
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
+var severityLevels = map[Severity]int{
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityError:    3,
+	SeverityCritical: 4,
+}
+
+// defaultSeverities 는 에러 코드별 기본 심각도입니다.
+var defaultSeverities = map[ErrorCode]Severity{
+	ErrorKeyNotFound:    SeverityWarning,
+	ErrorIndexNotFound:  SeverityWarning,
+	ErrorTypeUnmatched:  SeverityError,
+	ErrorValueUnmatched: SeverityWarning,
+	ErrorBaselineStale:  SeverityInfo,
+	ErrorSchemaViolated: SeverityError,
+	ErrorDefaultEqual:   SeverityInfo,
+
+	ErrorChangedInLHS:       SeverityInfo,
+	ErrorChangedInRHS:       SeverityInfo,
+	ErrorChangedIdentically: SeverityInfo,
+	ErrorThreeWayConflict:   SeverityError,
+
+	ErrorBreakingChange:    SeverityError,
+	ErrorNonBreakingChange: SeverityInfo,
+}
+
+func NewSeverity(severity string) (Severity, error) {
+	if _, ok := severityLevels[Severity(severity)]; !ok {
+		return "", fmt.Errorf("unsupported severity: %s", severity)
+	}
+
+	return Severity(severity), nil
+}
+
+func DefaultSeverity(code ErrorCode) Severity {
+	if severity, ok := defaultSeverities[code]; ok {
+		return severity
+	}
+
+	return SeverityWarning
+}
+
+func (s Severity) AtLeast(min Severity) bool {
+	return severityLevels[s] >= severityLevels[min]
+}
+
+// FilterBySeverity 는 min 이상의 심각도를 가진 결과만 반환합니다.
+func (er ErrorResults) FilterBySeverity(min Severity) ErrorResults {
+	filtered := make(ErrorResults, 0, len(er))
+	for _, result := range er {
+		if result.Severity.AtLeast(min) {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,41 @@
+package domain
+
+// CompareStats 는 비교 중 방문한 경로의 통계입니다.
+type CompareStats struct {
+	// KeysVisited 는 비교한 맵 키와 배열 요소의 수입니다.
+	KeysVisited int `json:"keysVisited"`
+	// ArraysCompared 는 비교한 배열의 수입니다.
+	ArraysCompared int `json:"arraysCompared"`
+	// IgnoredPaths 는 ignore 설정(--ignored-keys, 규칙, 프로파일)으로 비교하지 않은 경로의 수입니다.
+	IgnoredPaths int `json:"ignoredPaths"`
+}
+
+// Summary 는 리포트의 요약입니다. Stats 는 비교 통계가 없는 경우(ex. merge 명령어의 충돌 리포트) nil 입니다.
+type Summary struct {
+	Total      int               `json:"total"`
+	Stats      *CompareStats     `json:"stats,omitempty"`
+	ByCode     map[ErrorCode]int `json:"byErrorCode"`
+	BySeverity map[Severity]int  `json:"bySeverity"`
+	ByTopLevel map[string]int    `json:"byTopLevelKey"`
+}
+
+// Summarize 는 결과를 에러 코드, 심각도, 최상위 키별로 집계합니다.
+func (er ErrorResults) Summarize(stats *CompareStats) Summary {
+	summary := Summary{
+		Total:      len(er),
+		Stats:      stats,
+		ByCode:     map[ErrorCode]int{},
+		BySeverity: map[Severity]int{},
+		ByTopLevel: map[string]int{},
+	}
+
+	for _, result := range er {
+		summary.ByCode[result.ErrorCode]++
+		summary.BySeverity[result.Severity]++
+		if segments := SplitPath(result.Key); len(segments) > 0 {
+			summary.ByTopLevel[segments[0]]++
+		}
+	}
+
+	return summary
+}
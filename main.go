@@ -2,12 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
+	"slices"
 
+	"github.com/illuminarean-labs/yaml-diff-reporter/baseline"
+	"github.com/illuminarean-labs/yaml-diff-reporter/catalog"
 	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
 	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/illuminarean-labs/yaml-diff-reporter/masker"
+	"github.com/illuminarean-labs/yaml-diff-reporter/openapi"
 	"github.com/illuminarean-labs/yaml-diff-reporter/parser"
+	"github.com/illuminarean-labs/yaml-diff-reporter/profile"
 	"github.com/illuminarean-labs/yaml-diff-reporter/reporter"
+	"github.com/illuminarean-labs/yaml-diff-reporter/rules"
+	"github.com/illuminarean-labs/yaml-diff-reporter/schema"
 
 	"github.com/urfave/cli/v3"
 )
@@ -19,33 +28,229 @@ func main() {
 		outputPath string
 		modes      []string
 
+		basePath  string
+		baseAlias string
+
+		lhsLayers      []string
+		rhsLayers      []string
+		annotateLayers bool
+
+		lhsPatches []string
+		rhsPatches []string
+		mergeKeys  []string
+
+		resolveRefs bool
+
+		ageKeyFile string
+
+		mask          bool
+		maskKeys      []string
+		maskStyle     string
+		maskDigestKey string
+
+		rulesPath      string
+		schemaPath     string
+		profileName    string
+		schemaDefaults string
+		minSeverity    string
+
+		baselinePath      string
+		writeBaselinePath string
+
 		lhsAlias string
 		rhsAlias string
 
-		ignoredKeys []string
-		outputType  string
-		format      string
-		language    string
+		ignoredKeys  []string
+		outputType   string
+		format       string
+		language     string
+		catalogPaths []string
+		summaryOnly  bool
+		groupBy      string
+		sortBy       string
+		templatePath string
+		view         string
+		contextLines int64
 	)
 
 	cmd := &cli.Command{
 		Name:  "yaml-diff-reporter",
 		Usage: "Compare two yaml files and generate a report",
+		Commands: []*cli.Command{
+			mergeCommand(),
+			matrixCommand(),
+		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "lhs-path",
-				Usage:       "Path to the left-hand-side yaml file",
-				Required:    true,
+				Usage:       "Path to the left-hand-side yaml file (required)",
+				Required:    false,
 				Destination: &lhsPath,
 				Aliases:     []string{"l"},
 			},
 			&cli.StringFlag{
 				Name:        "rhs-path",
-				Usage:       "Path to the right-hand-side yaml file",
-				Required:    true,
+				Usage:       "Path to the right-hand-side yaml file (required)",
+				Required:    false,
 				Destination: &rhsPath,
 				Aliases:     []string{"r"},
 			},
+			&cli.StringFlag{
+				Name:        "base",
+				Usage:       "Path to the common ancestor yaml file for a three-way comparison",
+				Aliases:     []string{"B"},
+				Required:    false,
+				Destination: &basePath,
+			},
+			&cli.StringFlag{
+				Name:        "base-alias",
+				Usage:       "Alias for the common ancestor yaml",
+				Aliases:     []string{"ba"},
+				Required:    false,
+				Value:       "base",
+				Destination: &baseAlias,
+			},
+			&cli.StringSliceFlag{
+				Name:        "lhs-layers",
+				Usage:       "Values files deep-merged over the left-hand-side yaml, in order (helm semantics)",
+				Aliases:     []string{"ll"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &lhsLayers,
+			},
+			&cli.StringSliceFlag{
+				Name:        "rhs-layers",
+				Usage:       "Values files deep-merged over the right-hand-side yaml, in order (helm semantics)",
+				Aliases:     []string{"rl"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &rhsLayers,
+			},
+			&cli.BoolFlag{
+				Name:        "annotate-layers",
+				Usage:       "Annotate each difference with the layer file that contributed the value",
+				Aliases:     []string{"al"},
+				Required:    false,
+				Destination: &annotateLayers,
+			},
+			&cli.BoolFlag{
+				Name:        "resolve-refs",
+				Usage:       "Inline $ref and !include references to other files before comparison (differences are annotated with the originating file)",
+				Aliases:     []string{"rr"},
+				Required:    false,
+				Destination: &resolveRefs,
+			},
+			&cli.StringSliceFlag{
+				Name:        "lhs-patches",
+				Usage:       "Strategic merge patches applied to the left-hand-side yaml, in order",
+				Aliases:     []string{"lp"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &lhsPatches,
+			},
+			&cli.StringSliceFlag{
+				Name:        "rhs-patches",
+				Usage:       "Strategic merge patches applied to the right-hand-side yaml, in order",
+				Aliases:     []string{"rp"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &rhsPatches,
+			},
+			&cli.StringSliceFlag{
+				Name:        "merge-keys",
+				Usage:       "List merge keys for strategic merge patches (field=key, ex. containers=name)",
+				Aliases:     []string{"mk"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &mergeKeys,
+			},
+			&cli.StringFlag{
+				Name:        "age-key-file",
+				Usage:       "Path to an age key file used to decrypt SOPS-encrypted values (decrypted values are always masked)",
+				Aliases:     []string{"ak"},
+				Required:    false,
+				Destination: &ageKeyFile,
+			},
+			&cli.BoolFlag{
+				Name:        "mask",
+				Usage:       "Mask secret values (sensitive keys, mask-keys patterns and high-entropy strings) in reports",
+				Required:    false,
+				Value:       true,
+				Destination: &mask,
+			},
+			&cli.StringSliceFlag{
+				Name:        "mask-keys",
+				Usage:       "Additional path patterns whose values are masked (ex. database.*.dsn)",
+				Aliases:     []string{"sk"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &maskKeys,
+			},
+			&cli.StringFlag{
+				Name:        "mask-style",
+				Usage:       "How masked values are shown (redact, hash)",
+				Aliases:     []string{"ms"},
+				Required:    false,
+				Value:       "redact",
+				Destination: &maskStyle,
+			},
+			&cli.StringFlag{
+				Name:        "mask-digest-key",
+				Usage:       "HMAC key for masked value digests, used to detect secret changes in --mask-style hash and baselines (digests are omitted without it)",
+				Aliases:     []string{"mdk"},
+				Required:    false,
+				Destination: &maskDigestKey,
+			},
+			&cli.StringFlag{
+				Name:        "rules",
+				Usage:       "Path to a rules file describing per-path comparison policy",
+				Aliases:     []string{"R"},
+				Required:    false,
+				Destination: &rulesPath,
+			},
+			&cli.StringFlag{
+				Name:        "profile",
+				Usage:       "Diff profile (kubernetes, compose, github-actions, openapi, none, or a profile file; auto-detected when omitted)",
+				Aliases:     []string{"pf"},
+				Required:    false,
+				Destination: &profileName,
+			},
+			&cli.StringFlag{
+				Name:        "schema",
+				Usage:       "Path to a JSON Schema (draft 2020-12) file both yaml files are validated against",
+				Aliases:     []string{"sc"},
+				Required:    false,
+				Destination: &schemaPath,
+			},
+			&cli.StringFlag{
+				Name:        "schema-defaults",
+				Usage:       "How keys that only differ by a schema default are handled (report: DEFAULT_EQUIVALENT, suppress: not reported, off: do not fill defaults)",
+				Aliases:     []string{"sd"},
+				Required:    false,
+				Value:       "report",
+				Destination: &schemaDefaults,
+			},
+			&cli.StringFlag{
+				Name:        "min-severity",
+				Usage:       "Minimum severity to report; exits with status 1 when any remain (info, warning, error, critical)",
+				Aliases:     []string{"S"},
+				Required:    false,
+				Destination: &minSeverity,
+			},
+			&cli.StringFlag{
+				Name:        "baseline",
+				Usage:       "Path to a baseline file of expected differences to suppress",
+				Aliases:     []string{"b"},
+				Required:    false,
+				Destination: &baselinePath,
+			},
+			&cli.StringFlag{
+				Name:        "write-baseline",
+				Usage:       "Write the current differences to a baseline file",
+				Aliases:     []string{"wb"},
+				Required:    false,
+				Destination: &writeBaselinePath,
+			},
 			&cli.StringFlag{
 				Name:        "output-path",
 				Usage:       "Path to the output file",
@@ -55,7 +260,7 @@ func main() {
 			},
 			&cli.StringSliceFlag{
 				Name:        "modes",
-				Usage:       "Compare modes (type, key, index, value)",
+				Usage:       "Compare modes (type, key, index, value, subset, pattern)",
 				Aliases:     []string{"M"},
 				Required:    false,
 				Value:       []string{"type", "key", "index", "value"},
@@ -79,7 +284,7 @@ func main() {
 			},
 			&cli.StringFlag{
 				Name:        "format",
-				Usage:       "Report format (json, markdown, plain)",
+				Usage:       "Report format (json, markdown, plain, html, terminal, unified)",
 				Aliases:     []string{"f"},
 				Required:    false,
 				Value:       "json",
@@ -87,12 +292,64 @@ func main() {
 			},
 			&cli.StringFlag{
 				Name:        "language",
-				Usage:       "Report language (en: english, ko: korean)",
+				Usage:       "Report language (en: english, ko: korean, or a language added with --catalog)",
 				Aliases:     []string{"lang"},
 				Required:    false,
 				Value:       "en",
 				Destination: &language,
 			},
+			&cli.StringSliceFlag{
+				Name:        "catalog",
+				Usage:       "Message catalog file adding a report language or overriding messages (can be specified multiple times)",
+				Aliases:     []string{"cat"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &catalogPaths,
+			},
+			&cli.StringFlag{
+				Name:        "group-by",
+				Usage:       "Group differences in json, markdown and plain reports (path, section, code, file)",
+				Aliases:     []string{"gb"},
+				Required:    false,
+				Destination: &groupBy,
+			},
+			&cli.StringFlag{
+				Name:        "sort-by",
+				Usage:       "Sort differences (code, path, severity, file)",
+				Aliases:     []string{"sb"},
+				Required:    false,
+				Destination: &sortBy,
+			},
+			&cli.BoolFlag{
+				Name:        "summary-only",
+				Usage:       "Print only the summary (totals by error code, severity and top-level key) without individual differences (json, markdown, plain)",
+				Aliases:     []string{"so"},
+				Required:    false,
+				Destination: &summaryOnly,
+			},
+			&cli.StringFlag{
+				Name:        "template",
+				Usage:       "Go template file used to render the report instead of --format (.html files use html/template)",
+				Aliases:     []string{"tpl"},
+				Required:    false,
+				Destination: &templatePath,
+			},
+			&cli.StringFlag{
+				Name:        "view",
+				Usage:       "Diff view for the terminal format (side-by-side, unified)",
+				Aliases:     []string{"vw"},
+				Required:    false,
+				Value:       string(reporter.SideBySideView),
+				Destination: &view,
+			},
+			&cli.IntFlag{
+				Name:        "context",
+				Usage:       "Number of unchanged lines shown around each change in the terminal and unified formats",
+				Aliases:     []string{"C"},
+				Required:    false,
+				Value:       3,
+				Destination: &contextLines,
+			},
 			&cli.StringFlag{
 				Name:        "lhs-alias",
 				Usage:       "Alias for the left-hand-side yaml",
@@ -112,35 +369,203 @@ func main() {
 		},
 
 		Action: func(ctx context.Context, command *cli.Command) error {
+			// 하위 명령어가 같은 이름의 플래그를 사용하므로 필수 여부는 직접 확인합니다.
+			if lhsPath == "" || rhsPath == "" {
+				return errors.New(`required flags "lhs-path, rhs-path" not set`)
+			}
+
+			messages, err := catalog.Load(catalog.Config{Language: domain.ReportLanguage(language), Paths: catalogPaths})
+			if err != nil {
+				return err
+			}
+
+			patchMergeKeys, err := parser.NewMergeKeys(mergeKeys)
+			if err != nil {
+				return err
+			}
+
+			selected, err := profile.Resolve(profileName, lhsPath)
+			if err != nil {
+				return err
+			}
+			applier := profile.New(profile.Config{Profile: selected})
+
+			// OpenAPI 명세는 공통 컴포넌트의 변경을 사용하는 엔드포인트의 경로로 보고하도록 항상 $ref 를 치환합니다.
+			isOpenAPI := selected.Based(profile.OpenAPI)
+
 			p := parser.New(parser.Config{
-				LHSPath: lhsPath,
-				RHSPath: rhsPath,
+				LHSPath:     lhsPath,
+				RHSPath:     rhsPath,
+				BasePath:    basePath,
+				LHSLayers:   lhsLayers,
+				RHSLayers:   rhsLayers,
+				LHSPatches:  lhsPatches,
+				RHSPatches:  rhsPatches,
+				MergeKeys:   patchMergeKeys,
+				AgeKeyFile:  ageKeyFile,
+				DocumentKey: selected.DocumentKey,
+				ResolveRefs: resolveRefs || isOpenAPI,
 			})
 			yamls, err := p.Parse()
 			if err != nil {
 				return err
 			}
 
+			yamls.LHS = applier.Normalize(yamls.LHS)
+			yamls.RHS = applier.Normalize(yamls.RHS)
+			yamls.Base = applier.Normalize(yamls.Base)
+
+			defaultsMode, err := schema.NewDefaultsMode(schemaDefaults)
+			if err != nil {
+				return err
+			}
+
+			var (
+				violations domain.ErrorResults
+				lhsFilled  []string
+				rhsFilled  []string
+			)
+			if schemaPath != "" {
+				v := schema.New(schema.Config{Path: schemaPath})
+				violations, err = v.Validate(yamls.LHS, yamls.RHS)
+				if err != nil {
+					return err
+				}
+
+				if defaultsMode != schema.IgnoreDefaults {
+					if yamls.LHS, lhsFilled, err = v.ApplyDefaults(yamls.LHS); err != nil {
+						return err
+					}
+					if yamls.RHS, rhsFilled, err = v.ApplyDefaults(yamls.RHS); err != nil {
+						return err
+					}
+				}
+			}
+
+			// 비교는 원본 문서로 하고, 리포트에 표시되는 값만 마스킹합니다.
+			var m masker.Masker
+			displayLHS, displayRHS := yamls.LHS, yamls.RHS
+			encrypted := slices.Concat(yamls.LHSEncrypted, yamls.RHSEncrypted, yamls.BaseEncrypted)
+			if mask || len(encrypted) > 0 {
+				style, err := masker.NewMaskStyle(maskStyle)
+				if err != nil {
+					return err
+				}
+				if style == masker.Hash && maskDigestKey == "" {
+					return errors.New("--mask-style hash requires --mask-digest-key")
+				}
+
+				m = masker.New(masker.Config{
+					Patterns:     maskKeys,
+					Paths:        encrypted,
+					Style:        style,
+					PatternsOnly: !mask,
+					DigestKey:    maskDigestKey,
+				})
+				displayLHS, displayRHS = m.Mask(yamls.LHS), m.Mask(yamls.RHS)
+				violations.Redisplay(displayLHS, displayRHS)
+			}
+
+			var compareRules domain.Rules
+			if rulesPath != "" {
+				compareRules, err = rules.New(rules.Config{Path: rulesPath}).Load()
+				if err != nil {
+					return err
+				}
+			}
+
 			c := comparer.New(comparer.Config{
 				IgnoredKeys: ignoredKeys,
 				Modes:       domain.NewCompareModes(modes),
+				Rules:       append(compareRules, applier.Rules()...),
+				Masker:      m,
 			})
 
-			c.Compare("", yamls.LHS, yamls.RHS)
+			if basePath != "" {
+				c.CompareThreeWay("", yamls.Base, yamls.LHS, yamls.RHS)
+			} else {
+				c.Compare("", yamls.LHS, yamls.RHS)
+			}
+
+			results := c.Results()
+			stats := c.Stats()
+			if isOpenAPI && basePath == "" {
+				*results = openapi.Classify(*results, yamls.LHS, yamls.RHS)
+			}
+			*results = append(*results, violations...)
+			if defaultsMode == schema.ReportDefaults {
+				*results = append(*results, schema.Equivalents(displayLHS, displayRHS, lhsFilled, rhsFilled)...)
+			}
+			applier.Group(*results, yamls.LHS, yamls.RHS)
+			if annotateLayers || resolveRefs {
+				results.AnnotateOrigins(yamls.LHSOrigins, yamls.RHSOrigins)
+			}
+
+			if writeBaselinePath != "" {
+				return baseline.New(baseline.Config{Path: writeBaselinePath}).Write(*results)
+			}
+
+			if baselinePath != "" {
+				filtered, err := baseline.New(baseline.Config{Path: baselinePath}).Apply(*results)
+				if err != nil {
+					return err
+				}
+				results = &filtered
+			}
+
+			if minSeverity != "" {
+				severity, err := domain.NewSeverity(minSeverity)
+				if err != nil {
+					return err
+				}
+
+				filtered := results.FilterBySeverity(severity)
+				results = &filtered
+			}
+
+			diffView, err := reporter.NewDiffView(view)
+			if err != nil {
+				return err
+			}
+
+			resultGroupBy, err := reporter.NewGroupBy(groupBy)
+			if err != nil {
+				return err
+			}
+
+			resultSortBy, err := reporter.NewSortBy(sortBy)
+			if err != nil {
+				return err
+			}
 
 			r := reporter.New(reporter.Config{
-				Format:     domain.ReportFormat(format),
-				Language:   domain.ReportLanguage(language),
-				LHSAlias:   lhsAlias,
-				RHSAlias:   rhsAlias,
-				OutputPath: &outputPath,
-				OutputType: domain.ReportOutputType(outputType),
+				Format:       domain.ReportFormat(format),
+				Language:     domain.ReportLanguage(language),
+				LHSAlias:     lhsAlias,
+				RHSAlias:     rhsAlias,
+				BaseAlias:    baseAlias,
+				OutputPath:   &outputPath,
+				OutputType:   domain.ReportOutputType(outputType),
+				Catalog:      messages,
+				Stats:        &stats,
+				SummaryOnly:  summaryOnly,
+				GroupBy:      resultGroupBy,
+				SortBy:       resultSortBy,
+				TemplatePath: templatePath,
+				LHSDocument:  displayLHS,
+				RHSDocument:  displayRHS,
+				View:         diffView,
+				Context:      int(contextLines),
 			})
 
-			if err = r.Report(*c.Results()); err != nil {
+			if err = r.Report(*results); err != nil {
 				return err
 			}
 
+			if minSeverity != "" && !results.IsEmpty() {
+				return cli.Exit("", 1)
+			}
+
 			return nil
 		},
 	}
@@ -0,0 +1,231 @@
+package masker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+const (
+	Redact domain.MaskStyle = "redact"
+	Hash   domain.MaskStyle = "hash"
+)
+
+const (
+	redactedMarker = "[REDACTED]"
+
+	// 고엔트로피 문자열로 판단할 최소 길이와 문자당 엔트로피(bit)
+	minEntropyLength = 20
+	minEntropy       = 4.0
+)
+
+// sensitiveKeywords 는 키 이름에 포함되면 값을 마스킹하는 단어입니다.
+var sensitiveKeywords = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"accesskey",
+	"privatekey",
+	"credential",
+}
+
+func NewMaskStyle(style string) (domain.MaskStyle, error) {
+	switch domain.MaskStyle(style) {
+	case Redact, Hash:
+		return domain.MaskStyle(style), nil
+	default:
+		return "", fmt.Errorf("unsupported mask style: %s", style)
+	}
+}
+
+type Masker interface {
+	Mask(document map[string]any) map[string]any
+	// MaskValue 는 key 경로에 있는 value 를 Mask 와 같은 기준으로 마스킹합니다.
+	MaskValue(key string, value any) any
+}
+
+type Config struct {
+	Patterns []string
+	// Paths 는 패턴이 아닌 정확한 경로로 마스킹할 값입니다. ex. SOPS 로 복호화한 값의 경로
+	// 키에 포함된 "." 이나 "*" 를 패턴으로 해석하지 않습니다.
+	Paths []string
+	Style domain.MaskStyle
+	// PatternsOnly 가 true 인 경우 키 이름과 엔트로피 기반 판별 없이 Patterns, Paths 에 일치하는 값만 마스킹합니다.
+	PatternsOnly bool
+	// DigestKey 는 마스킹된 값의 Digest 를 계산하는 HMAC 키입니다.
+	// Digest 는 값의 변경 여부를 확인하는 용도로만 사용하며, 키가 없으면 Digest 를 남기지 않습니다.
+	DigestKey string
+}
+
+type masker struct {
+	config   Config
+	patterns []domain.PathPattern
+	paths    map[string]bool
+}
+
+func New(config Config) Masker {
+	patterns := make([]domain.PathPattern, 0, len(config.Patterns))
+	for _, pattern := range config.Patterns {
+		patterns = append(patterns, domain.NewPathPattern(pattern))
+	}
+
+	paths := make(map[string]bool, len(config.Paths))
+	for _, path := range config.Paths {
+		paths[path] = true
+	}
+
+	return masker{config: config, patterns: patterns, paths: paths}
+}
+
+// Mask 는 민감한 경로와 고엔트로피 문자열 값을 domain.MaskedValue 로 교체한 문서를 반환합니다.
+// 민감한 키 하위의 값은 모두 마스킹됩니다.
+func (m masker) Mask(document map[string]any) map[string]any {
+	masked, _ := m.mask("", document, false).(map[string]any)
+	return masked
+}
+
+// MaskValue 는 key 의 상위 경로 중 민감한 키가 있는 경우 value 전체를 마스킹합니다.
+// 비교는 원본 값으로 하고 결과에 표시할 값만 마스킹할 때 사용합니다.
+func (m masker) MaskValue(key string, value any) any {
+	return m.mask(key, value, m.isSensitivePath(key))
+}
+
+// isSensitivePath 는 key 자신 또는 상위 경로가 민감한 키인지 확인합니다.
+func (m masker) isSensitivePath(key string) bool {
+	path := ""
+	for _, segment := range domain.SplitPath(key) {
+		if strings.HasPrefix(segment, "[") {
+			path += segment
+			if m.matchesPattern(path) {
+				return true
+			}
+			continue
+		}
+
+		path = domain.MapKey(path, segment)
+		if m.isSensitiveKey(path, segment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m masker) mask(key string, value any, sensitive bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for childKey, childVal := range value {
+			nextKey := domain.MapKey(key, childKey)
+			result[childKey] = m.mask(nextKey, childVal, sensitive || m.isSensitiveKey(nextKey, childKey))
+		}
+		return result
+	case []any:
+		result := make([]any, 0, len(value))
+		for idx, elem := range value {
+			nextKey := domain.SliceKey(key, idx)
+			result = append(result, m.mask(nextKey, elem, sensitive || m.matchesPattern(nextKey)))
+		}
+		return result
+	case nil, domain.MaskedValue:
+		return value
+	default:
+		if sensitive || (!m.config.PatternsOnly && isHighEntropy(value)) {
+			return m.maskedValue(value)
+		}
+		return value
+	}
+}
+
+func (m masker) isSensitiveKey(key string, name string) bool {
+	if m.matchesPattern(key) {
+		return true
+	}
+
+	if m.config.PatternsOnly {
+		return false
+	}
+
+	normalized := strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(name))
+	for _, keyword := range sensitiveKeywords {
+		if strings.Contains(normalized, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m masker) matchesPattern(key string) bool {
+	if m.paths[key] {
+		return true
+	}
+
+	for _, pattern := range m.patterns {
+		if pattern.Match(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maskedValue 는 value 를 마스킹합니다.
+// Digest 는 DigestKey 로 계산한 HMAC-SHA256 의 앞 8자리로, 키를 모르면 짧은 비밀번호라도 원본 값을 대입해 찾을 수 없습니다.
+func (m masker) maskedValue(value any) domain.MaskedValue {
+	digest := ""
+	if m.config.DigestKey != "" {
+		mac := hmac.New(sha256.New, []byte(m.config.DigestKey))
+		mac.Write([]byte(fmt.Sprintf("%v", value)))
+		digest = hex.EncodeToString(mac.Sum(nil))[:8]
+	}
+
+	display := redactedMarker
+	if m.config.Style == Hash && digest != "" {
+		display = fmt.Sprintf("hmac:%s", digest)
+	}
+
+	return domain.MaskedValue{
+		Type:    domain.NewYAMLEntry(value).Type,
+		Display: display,
+		Digest:  digest,
+	}
+}
+
+// isHighEntropy 는 공백이나 URL 이 아닌 긴 문자열 중 섀넌 엔트로피가 높은 값을 찾습니다.
+func isHighEntropy(value any) bool {
+	str, ok := value.(string)
+	if !ok || len(str) < minEntropyLength {
+		return false
+	}
+
+	if strings.ContainsAny(str, " \t\n") || strings.Contains(str, "://") {
+		return false
+	}
+
+	return shannonEntropy(str) >= minEntropy
+}
+
+func shannonEntropy(str string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range str {
+		counts[r]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
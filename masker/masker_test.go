@@ -0,0 +1,116 @@
+package masker
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_masker_Mask(t *testing.T) {
+	type args struct {
+		config   Config
+		document map[string]any
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]any
+	}{
+		{
+			name: "민감한 키 이름의 값을 마스킹",
+			args: args{
+				config:   Config{Style: Redact, DigestKey: "test-key"},
+				document: map[string]any{"db": map[string]any{"host": "localhost", "DB_PASSWORD": "hunter2"}},
+			},
+			want: map[string]any{"db": map[string]any{
+				"host":        "localhost",
+				"DB_PASSWORD": domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "65f93b07"},
+			}},
+		},
+		{
+			name: "패턴에 일치하는 하위 값을 모두 마스킹",
+			args: args{
+				config:   Config{Patterns: []string{"db.*.dsn"}, Style: Hash, DigestKey: "test-key"},
+				document: map[string]any{"db": map[string]any{"primary": map[string]any{"dsn": []any{"a"}}}},
+			},
+			want: map[string]any{"db": map[string]any{"primary": map[string]any{
+				"dsn": []any{domain.MaskedValue{Type: "string", Display: "hmac:2a2a83b1", Digest: "2a2a83b1"}},
+			}}},
+		},
+		{
+			name: "고엔트로피 문자열을 마스킹",
+			args: args{
+				config:   Config{Style: Redact, DigestKey: "test-key"},
+				document: map[string]any{"key": "AKIAxK9fQ2mZ7pL3vB8nR1tW", "url": "https://example.com/some/long/path"},
+			},
+			want: map[string]any{
+				"key": domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "66c9606e"},
+				"url": "https://example.com/some/long/path",
+			},
+		},
+		{
+			name: "Paths 는 패턴이 아닌 정확한 경로로 일치",
+			args: args{
+				config:   Config{Paths: []string{"data.*"}, Style: Redact, PatternsOnly: true},
+				document: map[string]any{"data": map[string]any{"*": "a", "other": "b"}},
+			},
+			want: map[string]any{"data": map[string]any{
+				"*":     domain.MaskedValue{Type: "string", Display: "[REDACTED]"},
+				"other": "b",
+			}},
+		},
+		{
+			name: "HMAC 키가 없으면 Digest 없이 마스킹",
+			args: args{
+				config:   Config{Style: Hash},
+				document: map[string]any{"token": "hunter2"},
+			},
+			want: map[string]any{
+				"token": domain.MaskedValue{Type: "string", Display: "[REDACTED]"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.args.config).Mask(tt.args.document)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_masker_MaskValue(t *testing.T) {
+	m := New(Config{Patterns: []string{"db.*.dsn"}, Style: Redact, DigestKey: "test-key"})
+
+	tests := []struct {
+		name  string
+		key   string
+		value any
+		want  any
+	}{
+		{
+			name:  "민감한 상위 키",
+			key:   "auth.credentials.user",
+			value: "admin",
+			want:  domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "e0173abc"},
+		},
+		{
+			name:  "패턴에 일치하는 경로의 하위 트리",
+			key:   "db.primary.dsn",
+			value: []any{"a"},
+			want:  []any{domain.MaskedValue{Type: "string", Display: "[REDACTED]", Digest: "2a2a83b1"}},
+		},
+		{
+			name:  "민감하지 않은 경로",
+			key:   "image",
+			value: "app",
+			want:  "app",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.MaskValue(tt.key, tt.value))
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/catalog"
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/illuminarean-labs/yaml-diff-reporter/masker"
+	"github.com/illuminarean-labs/yaml-diff-reporter/parser"
+	"github.com/illuminarean-labs/yaml-diff-reporter/profile"
+	"github.com/illuminarean-labs/yaml-diff-reporter/reporter"
+	"github.com/illuminarean-labs/yaml-diff-reporter/rules"
+
+	"github.com/samber/lo"
+	"github.com/urfave/cli/v3"
+)
+
+func matrixCommand() *cli.Command {
+	var (
+		inputs     []string
+		reference  string
+		outputPath string
+		modes      []string
+
+		ignoredKeys  []string
+		rulesPath    string
+		outputType   string
+		format       string
+		language     string
+		catalogPaths []string
+
+		ageKeyFile    string
+		mask          bool
+		maskKeys      []string
+		maskStyle     string
+		maskDigestKey string
+
+		profileName string
+		resolveRefs bool
+	)
+
+	return &cli.Command{
+		Name:  "matrix",
+		Usage: "Compare more than two yaml files and generate an environment matrix report",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "input",
+				Usage:       "Input yaml file as alias=path (repeat for each environment)",
+				Aliases:     []string{"i"},
+				Required:    true,
+				Destination: &inputs,
+			},
+			&cli.StringFlag{
+				Name:        "reference",
+				Usage:       "Alias of the reference environment (default: deviations from the majority)",
+				Aliases:     []string{"ref"},
+				Required:    false,
+				Destination: &reference,
+			},
+			&cli.StringFlag{
+				Name:        "output-path",
+				Usage:       "Path to the output file",
+				Required:    false,
+				Destination: &outputPath,
+				Aliases:     []string{"o"},
+			},
+			&cli.StringSliceFlag{
+				Name:        "modes",
+				Usage:       "Compare modes (type, key, index, value, subset, pattern)",
+				Aliases:     []string{"M"},
+				Required:    false,
+				Value:       []string{"type", "key", "index", "value"},
+				Destination: &modes,
+			},
+			&cli.StringSliceFlag{
+				Name:        "ignored-keys",
+				Usage:       "Ignored keys",
+				Aliases:     []string{"I"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &ignoredKeys,
+			},
+			&cli.StringFlag{
+				Name:        "rules",
+				Usage:       "Path to a rules file describing per-path comparison policy",
+				Aliases:     []string{"R"},
+				Required:    false,
+				Destination: &rulesPath,
+			},
+			&cli.StringFlag{
+				Name:        "output-type",
+				Usage:       "Output type (stdout, file)",
+				Aliases:     []string{"ot"},
+				Required:    false,
+				Value:       "stdout",
+				Destination: &outputType,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "Report format (json, markdown, plain)",
+				Aliases:     []string{"f"},
+				Required:    false,
+				Value:       "json",
+				Destination: &format,
+			},
+			&cli.StringFlag{
+				Name:        "language",
+				Usage:       "Report language (en: english, ko: korean, or a language added with --catalog)",
+				Aliases:     []string{"lang"},
+				Required:    false,
+				Value:       "en",
+				Destination: &language,
+			},
+			&cli.StringSliceFlag{
+				Name:        "catalog",
+				Usage:       "Message catalog file adding a report language or overriding messages (can be specified multiple times)",
+				Aliases:     []string{"cat"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &catalogPaths,
+			},
+			&cli.StringFlag{
+				Name:        "age-key-file",
+				Usage:       "Path to an age key file used to decrypt SOPS-encrypted values (decrypted values are always masked)",
+				Aliases:     []string{"ak"},
+				Required:    false,
+				Destination: &ageKeyFile,
+			},
+			&cli.BoolFlag{
+				Name:        "mask",
+				Usage:       "Mask secret values (sensitive keys, mask-keys patterns and high-entropy strings) in reports",
+				Required:    false,
+				Value:       true,
+				Destination: &mask,
+			},
+			&cli.StringSliceFlag{
+				Name:        "mask-keys",
+				Usage:       "Additional path patterns whose values are masked (ex. database.*.dsn)",
+				Aliases:     []string{"sk"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &maskKeys,
+			},
+			&cli.StringFlag{
+				Name:        "mask-style",
+				Usage:       "How masked values are shown (redact, hash)",
+				Aliases:     []string{"ms"},
+				Required:    false,
+				Value:       "redact",
+				Destination: &maskStyle,
+			},
+			&cli.StringFlag{
+				Name:        "mask-digest-key",
+				Usage:       "HMAC key for masked value digests, used to detect secret changes in --mask-style hash and baselines (digests are omitted without it)",
+				Aliases:     []string{"mdk"},
+				Required:    false,
+				Destination: &maskDigestKey,
+			},
+			&cli.StringFlag{
+				Name:        "profile",
+				Usage:       "Diff profile (kubernetes, compose, github-actions, openapi, none, or a profile file; auto-detected from the first input when omitted)",
+				Aliases:     []string{"pf"},
+				Required:    false,
+				Destination: &profileName,
+			},
+			&cli.BoolFlag{
+				Name:        "resolve-refs",
+				Usage:       "Inline $ref and !include references to other files before comparison",
+				Aliases:     []string{"rr"},
+				Required:    false,
+				Destination: &resolveRefs,
+			},
+		},
+
+		Action: func(ctx context.Context, command *cli.Command) error {
+			if len(inputs) < 2 {
+				return errors.New("at least two inputs are required")
+			}
+			if !lo.Contains(reporter.MatrixFormats, domain.ReportFormat(format)) {
+				return fmt.Errorf("unsupported matrix report format: %s (json, markdown, plain)", format)
+			}
+
+			messages, err := catalog.Load(catalog.Config{Language: domain.ReportLanguage(language), Paths: catalogPaths})
+			if err != nil {
+				return err
+			}
+
+			_, firstPath, ok := strings.Cut(inputs[0], "=")
+			if !ok {
+				firstPath = inputs[0]
+			}
+			selected, err := profile.Resolve(profileName, firstPath)
+			if err != nil {
+				return err
+			}
+			applier := profile.New(profile.Config{Profile: selected})
+
+			p := parser.New(parser.Config{
+				AgeKeyFile:  ageKeyFile,
+				DocumentKey: selected.DocumentKey,
+				ResolveRefs: resolveRefs,
+			})
+			documents, err := p.ParseDocuments(inputs)
+			if err != nil {
+				return err
+			}
+			for idx := range documents {
+				documents[idx].Values = applier.Normalize(documents[idx].Values)
+			}
+
+			aliases := lo.Map(documents, func(document domain.Document, _ int) string {
+				return document.Alias
+			})
+			if reference != "" && !lo.Contains(aliases, reference) {
+				return fmt.Errorf("unknown reference alias: %s", reference)
+			}
+
+			encrypted := lo.FlatMap(documents, func(document domain.Document, _ int) []string {
+				return document.Encrypted
+			})
+			// 비교는 원본 문서로 하고, 매트릭스에 표시되는 값만 마스킹합니다.
+			var m masker.Masker
+			if mask || len(encrypted) > 0 {
+				style, err := masker.NewMaskStyle(maskStyle)
+				if err != nil {
+					return err
+				}
+				if style == masker.Hash && maskDigestKey == "" {
+					return errors.New("--mask-style hash requires --mask-digest-key")
+				}
+
+				m = masker.New(masker.Config{
+					Patterns:     maskKeys,
+					Paths:        encrypted,
+					Style:        style,
+					PatternsOnly: !mask,
+					DigestKey:    maskDigestKey,
+				})
+			}
+
+			var compareRules domain.Rules
+			if rulesPath != "" {
+				compareRules, err = rules.New(rules.Config{Path: rulesPath}).Load()
+				if err != nil {
+					return err
+				}
+			}
+
+			c := comparer.New(comparer.Config{
+				IgnoredKeys: ignoredKeys,
+				Modes:       domain.NewCompareModes(modes),
+				Rules:       append(compareRules, applier.Rules()...),
+				Masker:      m,
+			})
+			matrix := c.CompareMatrix(documents, reference)
+
+			r := reporter.New(reporter.Config{
+				Format:     domain.ReportFormat(format),
+				Language:   domain.ReportLanguage(language),
+				Catalog:    messages,
+				Aliases:    aliases,
+				OutputPath: &outputPath,
+				OutputType: domain.ReportOutputType(outputType),
+			})
+
+			return r.ReportMatrix(matrix)
+		},
+	}
+}
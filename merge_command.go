@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/catalog"
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/illuminarean-labs/yaml-diff-reporter/merger"
+	"github.com/illuminarean-labs/yaml-diff-reporter/parser"
+	"github.com/illuminarean-labs/yaml-diff-reporter/reporter"
+
+	"github.com/urfave/cli/v3"
+)
+
+func mergeCommand() *cli.Command {
+	var (
+		basePath      string
+		lhsPath       string
+		rhsPath       string
+		outputPath    string
+		conflictStyle string
+		format        string
+		language      string
+		catalogPaths  []string
+
+		lhsAlias string
+		rhsAlias string
+	)
+
+	return &cli.Command{
+		Name:  "merge",
+		Usage: "Three-way merge two yaml files against their common ancestor",
+		Description: "Takes non-conflicting changes from both sides automatically.\n" +
+			"Exits with status 1 when conflicts remain, so it can be registered as a git merge driver:\n\n" +
+			"  git config merge.yaml-diff.driver \"yaml-diff-reporter merge --base %O --lhs-path %A --rhs-path %B --output-path %A\"",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "base",
+				Usage:       "Path to the common ancestor yaml file",
+				Required:    true,
+				Destination: &basePath,
+				Aliases:     []string{"B"},
+			},
+			&cli.StringFlag{
+				Name:        "lhs-path",
+				Usage:       "Path to the left-hand-side yaml file (ours)",
+				Required:    true,
+				Destination: &lhsPath,
+				Aliases:     []string{"l"},
+			},
+			&cli.StringFlag{
+				Name:        "rhs-path",
+				Usage:       "Path to the right-hand-side yaml file (theirs)",
+				Required:    true,
+				Destination: &rhsPath,
+				Aliases:     []string{"r"},
+			},
+			&cli.StringFlag{
+				Name:        "output-path",
+				Usage:       "Path to write the merged yaml (default: stdout)",
+				Required:    false,
+				Destination: &outputPath,
+				Aliases:     []string{"o"},
+			},
+			&cli.StringFlag{
+				Name:        "conflict-style",
+				Usage:       "How conflicts are emitted (markers: in-file conflict markers, report: keep lhs values and print a conflict report to stderr)",
+				Aliases:     []string{"cs"},
+				Required:    false,
+				Value:       "markers",
+				Destination: &conflictStyle,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "Conflict report format (json, markdown, plain)",
+				Aliases:     []string{"f"},
+				Required:    false,
+				Value:       "plain",
+				Destination: &format,
+			},
+			&cli.StringFlag{
+				Name:        "language",
+				Usage:       "Conflict report language (en: english, ko: korean, or a language added with --catalog)",
+				Aliases:     []string{"lang"},
+				Required:    false,
+				Value:       "en",
+				Destination: &language,
+			},
+			&cli.StringSliceFlag{
+				Name:        "catalog",
+				Usage:       "Message catalog file adding a report language or overriding messages (can be specified multiple times)",
+				Aliases:     []string{"cat"},
+				Required:    false,
+				Value:       []string{},
+				Destination: &catalogPaths,
+			},
+			&cli.StringFlag{
+				Name:        "lhs-alias",
+				Usage:       "Alias for the left-hand-side yaml",
+				Aliases:     []string{"la"},
+				Required:    false,
+				Value:       "lhs",
+				Destination: &lhsAlias,
+			},
+			&cli.StringFlag{
+				Name:        "rhs-alias",
+				Usage:       "Alias for the right-hand-side yaml",
+				Aliases:     []string{"ra"},
+				Required:    false,
+				Value:       "rhs",
+				Destination: &rhsAlias,
+			},
+		},
+
+		Action: func(ctx context.Context, command *cli.Command) error {
+			style, err := merger.NewConflictStyle(conflictStyle)
+			if err != nil {
+				return err
+			}
+
+			messages, err := catalog.Load(catalog.Config{Language: domain.ReportLanguage(language), Paths: catalogPaths})
+			if err != nil {
+				return err
+			}
+
+			p := parser.New(parser.Config{})
+			base, err := p.ParseNode(basePath)
+			if err != nil {
+				return err
+			}
+			lhs, err := p.ParseNode(lhsPath)
+			if err != nil {
+				return err
+			}
+			rhs, err := p.ParseNode(rhsPath)
+			if err != nil {
+				return err
+			}
+
+			m := merger.New(merger.Config{
+				Comparer: comparer.Config{
+					Modes: domain.CompareModes{comparer.Type, comparer.Key, comparer.Index, comparer.Value},
+				},
+				ConflictStyle: style,
+				LHSAlias:      lhsAlias,
+				RHSAlias:      rhsAlias,
+			})
+
+			result, err := m.Merge(base, lhs, rhs)
+			if err != nil {
+				return err
+			}
+
+			merged, err := m.Render(result)
+			if err != nil {
+				return err
+			}
+
+			if outputPath == "" {
+				fmt.Print(string(merged))
+			} else if err = os.WriteFile(outputPath, merged, 0644); err != nil {
+				return err
+			}
+
+			if !result.HasConflicts() {
+				return nil
+			}
+
+			// 병합된 문서를 stdout 으로 출력하는 경우에도 섞이지 않도록 충돌 리포트는 stderr 로 출력합니다.
+			if style == merger.Report {
+				r := reporter.New(reporter.Config{
+					Format:     domain.ReportFormat(format),
+					Language:   domain.ReportLanguage(language),
+					Catalog:    messages,
+					LHSAlias:   lhsAlias,
+					RHSAlias:   rhsAlias,
+					BaseAlias:  "base",
+					OutputType: reporter.Stderr,
+				})
+				if err = r.Report(result.Conflicts); err != nil {
+					return err
+				}
+			}
+
+			return cli.Exit(fmt.Sprintf("%d conflict(s) found", len(result.Conflicts)), 1)
+		},
+	}
+}
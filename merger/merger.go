@@ -0,0 +1,530 @@
+package merger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Markers domain.ConflictStyle = "markers"
+	Report  domain.ConflictStyle = "report"
+)
+
+const conflictToken = "__YAML_DIFF_REPORTER_CONFLICT_%d__"
+
+type Merger interface {
+	Merge(base *yaml.Node, lhs *yaml.Node, rhs *yaml.Node) (domain.MergeResult, error)
+	Render(result domain.MergeResult) ([]byte, error)
+}
+
+type Config struct {
+	Comparer      comparer.Config
+	ConflictStyle domain.ConflictStyle
+	LHSAlias      string
+	RHSAlias      string
+}
+
+type merger struct {
+	config Config
+}
+
+func New(config Config) Merger {
+	return merger{config: config}
+}
+
+func NewConflictStyle(style string) (domain.ConflictStyle, error) {
+	switch domain.ConflictStyle(style) {
+	case Markers, Report:
+		return domain.ConflictStyle(style), nil
+	default:
+		return "", fmt.Errorf("unsupported conflict style: %s", style)
+	}
+}
+
+// Merge 는 3-way 비교 결과를 바탕으로 lhs 에 rhs 쪽에서만 변경된 값을 적용합니다.
+// 충돌이 발생한 경로는 lhs 의 값을 유지하고 Conflicts 에 기록합니다.
+// 병합은 lhs 문서의 노드 위에서 이루어지므로 키 순서와 주석이 유지되며, rhs 에서 추가된 키는 rhs 의 순서를 따라 삽입됩니다.
+func (m merger) Merge(base *yaml.Node, lhs *yaml.Node, rhs *yaml.Node) (domain.MergeResult, error) {
+	base, lhs, rhs = documentNode(base), documentNode(lhs), documentNode(rhs)
+
+	baseValues, err := decode(base)
+	if err != nil {
+		return domain.MergeResult{}, err
+	}
+	lhsValues, err := decode(lhs)
+	if err != nil {
+		return domain.MergeResult{}, err
+	}
+	rhsValues, err := decode(rhs)
+	if err != nil {
+		return domain.MergeResult{}, err
+	}
+
+	c := comparer.New(m.config.Comparer)
+	c.CompareThreeWay("", baseValues, lhsValues, rhsValues)
+
+	merged := copyNode(lhs)
+
+	// lhs 에서 요소가 추가되거나 삭제된 배열은 rhs 의 인덱스 경로와 맞지 않으므로, rhs 도 변경한 경우 배열 전체를 충돌로 처리합니다.
+	misaligned := make(map[string]bool)
+	for _, result := range *c.Results() {
+		if result.ErrorCode != domain.ErrorChangedInRHS {
+			continue
+		}
+		if list, ok := misalignedList(result.Key, baseValues, lhsValues); ok {
+			misaligned[list] = true
+		}
+	}
+
+	var (
+		updates   []string
+		deletions []string
+		conflicts domain.ErrorResults
+	)
+	for _, list := range sortedKeys(misaligned) {
+		baseVal, _ := domain.Lookup(baseValues, list)
+		lhsVal, _ := domain.Lookup(lhsValues, list)
+		rhsVal, _ := domain.Lookup(rhsValues, list)
+		conflicts = append(conflicts, domain.ThreeWayResult(list, domain.ErrorThreeWayConflict, baseVal, lhsVal, rhsVal))
+	}
+
+	for _, result := range *c.Results() {
+		if underAny(result.Key, misaligned) {
+			continue
+		}
+
+		switch result.ErrorCode {
+		case domain.ErrorChangedInRHS:
+			if _, ok := domain.Lookup(rhsValues, result.Key); ok {
+				updates = append(updates, result.Key)
+			} else {
+				deletions = append(deletions, result.Key)
+			}
+		case domain.ErrorThreeWayConflict:
+			if result.Key == "" {
+				return domain.MergeResult{}, errors.New("documents conflict at the root")
+			}
+			conflicts = append(conflicts, result)
+		}
+	}
+
+	// 배열에 추가되는 인덱스는 앞에서부터, 삭제되는 인덱스는 뒤에서부터 적용해야 경로가 유지됩니다.
+	sort.Slice(updates, func(i, j int) bool {
+		return pathLess(updates[i], updates[j])
+	})
+	sort.Slice(deletions, func(i, j int) bool {
+		return pathLess(deletions[j], deletions[i])
+	})
+
+	for _, key := range updates {
+		value, _ := lookupNode(rhs, key)
+		if err = setNode(merged, rhs, key, copyNode(value)); err != nil {
+			return domain.MergeResult{}, err
+		}
+	}
+	for _, key := range deletions {
+		if err = deleteNode(merged, key); err != nil {
+			return domain.MergeResult{}, err
+		}
+	}
+
+	return domain.MergeResult{
+		Merged:    merged,
+		LHS:       lhs,
+		RHS:       rhs,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// misalignedList 는 key 경로가 거치는 배열 중 base 와 lhs 의 길이가 다른 가장 바깥쪽 배열의 경로를 반환합니다.
+func misalignedList(key string, base map[string]any, lhs map[string]any) (string, bool) {
+	path := ""
+	for _, segment := range domain.SplitPath(key) {
+		if !strings.HasPrefix(segment, "[") {
+			path = domain.MapKey(path, segment)
+			continue
+		}
+
+		baseList, _ := domain.Lookup(base, path)
+		lhsList, _ := domain.Lookup(lhs, path)
+		baseElems, baseOk := baseList.([]any)
+		lhsElems, lhsOk := lhsList.([]any)
+		if baseOk && lhsOk && len(baseElems) != len(lhsElems) {
+			return path, true
+		}
+		path += segment
+	}
+
+	return "", false
+}
+
+// underAny 는 key 가 paths 중 하나이거나 그 하위 경로인지 확인합니다.
+func underAny(key string, paths map[string]bool) bool {
+	for path := range paths {
+		if key == path || strings.HasPrefix(key, path+".") || strings.HasPrefix(key, path+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Render 는 병합 결과를 YAML 로 직렬화합니다.
+// markers 스타일인 경우 충돌 경로를 git 과 같은 충돌 마커로 감싸 양쪽 값을 모두 기록합니다.
+func (m merger) Render(result domain.MergeResult) ([]byte, error) {
+	if m.config.ConflictStyle != Markers || len(result.Conflicts) == 0 {
+		return marshal(result.Merged)
+	}
+
+	document := copyNode(result.Merged)
+	tokens := make(map[string]domain.ErrorResult, len(result.Conflicts))
+	for idx, conflict := range result.Conflicts {
+		token := fmt.Sprintf(conflictToken, idx)
+		tokens[token] = conflict
+		if err := setNode(document, result.RHS, conflict.Key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: token}); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		token, conflict, ok := findToken(line, tokens)
+		if !ok {
+			out.WriteString(line)
+			continue
+		}
+
+		prefix := line[:strings.Index(line, token)]
+		lhsVal, lhsOk := lookupNode(result.LHS, conflict.Key)
+		rhsVal, rhsOk := lookupNode(result.RHS, conflict.Key)
+
+		out.WriteString(fmt.Sprintf("<<<<<<< %s\n", m.config.LHSAlias))
+		if lhsOk {
+			if err = writeEntry(&out, prefix, lhsVal); err != nil {
+				return nil, err
+			}
+		}
+		out.WriteString("=======\n")
+		if rhsOk {
+			if err = writeEntry(&out, prefix, rhsVal); err != nil {
+				return nil, err
+			}
+		}
+		out.WriteString(fmt.Sprintf(">>>>>>> %s\n", m.config.RHSAlias))
+	}
+
+	return out.Bytes(), nil
+}
+
+func findToken(line string, tokens map[string]domain.ErrorResult) (string, domain.ErrorResult, bool) {
+	for token, conflict := range tokens {
+		if strings.Contains(line, token) {
+			return token, conflict, true
+		}
+	}
+
+	return "", domain.ErrorResult{}, false
+}
+
+// writeEntry 는 "key: " 또는 "- " 로 끝나는 prefix 뒤에 value 를 YAML 로 기록합니다.
+// 앞 주석은 마커 바깥의 줄과 섞이지 않도록 제외합니다.
+func writeEntry(out *bytes.Buffer, prefix string, value *yaml.Node) error {
+	entry := *value
+	entry.HeadComment = ""
+
+	content, err := marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	// flow 스타일 컨테이너([a, b])는 스칼라처럼 key 와 같은 줄에 기록합니다.
+	isContainer := (value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode) && value.Style&yaml.FlowStyle == 0
+	isListItem := strings.HasSuffix(prefix, "- ")
+
+	if isContainer && !isListItem && len(lines) > 0 && lines[0] != "{}" && lines[0] != "[]" {
+		indent := strings.Repeat(" ", len(prefix)-len(strings.TrimLeft(prefix, " -"))+2)
+		out.WriteString(strings.TrimRight(prefix, " ") + "\n")
+		for _, line := range lines {
+			out.WriteString(indent + line + "\n")
+		}
+		return nil
+	}
+
+	out.WriteString(prefix + lines[0] + "\n")
+	indent := strings.Repeat(" ", len(prefix))
+	for _, line := range lines[1:] {
+		out.WriteString(indent + line + "\n")
+	}
+
+	return nil
+}
+
+func marshal(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// documentNode 는 비어 있는 파일도 빈 맵 문서로 다룰 수 있도록 문서 노드를 반환합니다.
+func documentNode(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node
+	}
+
+	return &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+	}
+}
+
+// decode 는 비교에 사용할 수 있도록 문서 노드를 맵으로 변환합니다.
+func decode(document *yaml.Node) (map[string]any, error) {
+	var values map[string]any
+	if err := document.Decode(&values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	return values, nil
+}
+
+// location 은 경로의 마지막 값이 속한 컨테이너 노드와 그 안의 위치입니다.
+// 맵인 경우 index 는 키 노드의 위치(없으면 -1)이고, 배열인 경우 요소의 인덱스입니다.
+type location struct {
+	parent *yaml.Node
+	key    string
+	index  int
+}
+
+func (l location) value() *yaml.Node {
+	switch {
+	case l.index < 0:
+		return nil
+	case l.parent.Kind == yaml.MappingNode:
+		return l.parent.Content[l.index+1]
+	case l.index < len(l.parent.Content):
+		return l.parent.Content[l.index]
+	default:
+		return nil
+	}
+}
+
+// locate 는 domain.Lookup 과 같은 방식으로 key 경로를 따라가며, 점이 포함된 맵 키도 찾습니다.
+func locate(document *yaml.Node, key string) (location, bool) {
+	if key == "" {
+		return location{}, false
+	}
+
+	return locateSegments(document.Content[0], domain.SplitPath(key))
+}
+
+func locateSegments(node *yaml.Node, segments []string) (location, bool) {
+	segment := segments[0]
+	if strings.HasPrefix(segment, "[") {
+		if node.Kind != yaml.SequenceNode {
+			return location{}, false
+		}
+
+		idx, err := strconv.Atoi(strings.Trim(segment, "[]"))
+		if err != nil || idx < 0 {
+			return location{}, false
+		}
+		if len(segments) == 1 {
+			return location{parent: node, key: segment, index: idx}, true
+		}
+		if idx >= len(node.Content) {
+			return location{}, false
+		}
+
+		return locateSegments(node.Content[idx], segments[1:])
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return location{}, false
+	}
+
+	for end := 1; end <= len(segments); end++ {
+		if end > 1 && strings.HasPrefix(segments[end-1], "[") {
+			break
+		}
+
+		key := strings.Join(segments[:end], ".")
+		idx := mappingIndex(node, key)
+		if idx < 0 {
+			continue
+		}
+		if end == len(segments) {
+			return location{parent: node, key: key, index: idx}, true
+		}
+		if found, ok := locateSegments(node.Content[idx+1], segments[end:]); ok {
+			return found, true
+		}
+	}
+
+	// 없는 키는 남은 경로가 모두 맵 키인 경우에만 새 키의 위치로 반환합니다.
+	for _, rest := range segments {
+		if strings.HasPrefix(rest, "[") {
+			return location{}, false
+		}
+	}
+
+	return location{parent: node, key: strings.Join(segments, "."), index: -1}, true
+}
+
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for idx := 0; idx+1 < len(mapping.Content); idx += 2 {
+		if mapping.Content[idx].Value == key {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func lookupNode(document *yaml.Node, key string) (*yaml.Node, bool) {
+	if key == "" {
+		return document.Content[0], true
+	}
+
+	found, ok := locate(document, key)
+	if !ok {
+		return nil, false
+	}
+
+	value := found.value()
+	return value, value != nil
+}
+
+// setNode 는 document 의 key 경로에 value 를 기록합니다.
+// 새로 추가하는 맵 키는 source 문서의 키 노드(주석 포함)를 복사하고, source 에서 앞에 있는 키 뒤에 삽입합니다.
+func setNode(document *yaml.Node, source *yaml.Node, key string, value *yaml.Node) error {
+	found, ok := locate(document, key)
+	if !ok {
+		return fmt.Errorf("failed to merge %s: parent not found", key)
+	}
+
+	parent := found.parent
+	switch {
+	case parent.Kind == yaml.MappingNode && found.index >= 0:
+		parent.Content[found.index+1] = value
+		return nil
+	case parent.Kind == yaml.MappingNode:
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: found.key}
+		position := 0
+		if sourceFound, ok := locate(source, key); ok && sourceFound.index >= 0 {
+			keyNode = copyNode(sourceFound.parent.Content[sourceFound.index])
+			position = insertPosition(parent, sourceFound.parent, sourceFound.index)
+		}
+
+		content := append([]*yaml.Node{}, parent.Content[:position]...)
+		content = append(content, keyNode, value)
+		parent.Content = append(content, parent.Content[position:]...)
+		return nil
+	case found.index < len(parent.Content):
+		parent.Content[found.index] = value
+		return nil
+	case found.index == len(parent.Content):
+		parent.Content = append(parent.Content, value)
+		return nil
+	default:
+		return fmt.Errorf("failed to merge %s: index out of range", key)
+	}
+}
+
+// insertPosition 은 source 맵에서 sourceIndex 의 키보다 앞에 있는 키 중 mapping 에도 있는 가장 가까운 키의 다음 위치를 반환합니다.
+// 그런 키가 없으면 맨 앞에 삽입합니다.
+func insertPosition(mapping *yaml.Node, source *yaml.Node, sourceIndex int) int {
+	for idx := sourceIndex - 2; idx >= 0; idx -= 2 {
+		if position := mappingIndex(mapping, source.Content[idx].Value); position >= 0 {
+			return position + 2
+		}
+	}
+
+	return 0
+}
+
+func deleteNode(document *yaml.Node, key string) error {
+	found, ok := locate(document, key)
+	if !ok || found.index < 0 {
+		return nil
+	}
+
+	parent := found.parent
+	switch {
+	case parent.Kind == yaml.MappingNode:
+		parent.Content = append(parent.Content[:found.index:found.index], parent.Content[found.index+2:]...)
+	case found.index < len(parent.Content):
+		parent.Content = append(parent.Content[:found.index:found.index], parent.Content[found.index+1:]...)
+	}
+
+	return nil
+}
+
+func copyNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for idx, child := range node.Content {
+		copied.Content[idx] = copyNode(child)
+	}
+
+	return &copied
+}
+
+// pathLess 는 배열 인덱스를 숫자로 비교하는 경로 정렬 기준입니다.
+func pathLess(a string, b string) bool {
+	as, bs := domain.SplitPath(a), domain.SplitPath(b)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+
+		ai, aErr := strconv.Atoi(strings.Trim(as[i], "[]"))
+		bi, bErr := strconv.Atoi(strings.Trim(bs[i], "[]"))
+		if aErr == nil && bErr == nil {
+			return ai < bi
+		}
+
+		return as[i] < bs[i]
+	}
+
+	return len(as) < len(bs)
+}
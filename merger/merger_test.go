@@ -0,0 +1,133 @@
+package merger
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func newTestMerger(style domain.ConflictStyle) Merger {
+	return New(Config{
+		Comparer: comparer.Config{
+			Modes: domain.CompareModes{comparer.Type, comparer.Key, comparer.Index, comparer.Value},
+		},
+		ConflictStyle: style,
+		LHSAlias:      "ours",
+		RHSAlias:      "theirs",
+	})
+}
+
+func parseNode(t *testing.T, content string) *yaml.Node {
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(content), &node))
+
+	return &node
+}
+
+func Test_merger_Merge(t *testing.T) {
+	type args struct {
+		base string
+		lhs  string
+		rhs  string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		want          string
+		wantConflicts []string
+	}{
+		{
+			name: "충돌하지 않는 변경을 모두 반영",
+			args: args{
+				base: "a: 1\nb: 1\nc: 1\nlist: [x, y, z]\n",
+				lhs:  "a: 2\nb: 1\nc: 1\nlist: [x, y, z]\n",
+				rhs:  "a: 1\nb: 3\nlist: [x]\nd: 1\n",
+			},
+			want:          "a: 2\nb: 3\nlist: [x]\nd: 1\n",
+			wantConflicts: nil,
+		},
+		{
+			name: "충돌한 경로는 lhs 값을 유지",
+			args: args{
+				base: "a: 1\nb: 1\n",
+				lhs:  "a: 2\nb: 1\n",
+				rhs:  "a: 3\nb: 2\n",
+			},
+			want:          "a: 2\nb: 2\n",
+			wantConflicts: []string{"a"},
+		},
+		{
+			name: "키 순서와 주석을 유지하고 추가된 키는 rhs 의 위치에 삽입",
+			args: args{
+				base: "# app settings\nzeta: 1\nalpha:\n  port: 80 # http\n",
+				lhs:  "# app settings\nzeta: 2\nalpha:\n  port: 80 # http\n",
+				rhs:  "# app settings\nzeta: 1\n# added by theirs\nmiddle: true\nalpha:\n  port: 80 # http\n  host: example.com\n",
+			},
+			want:          "# app settings\nzeta: 2\n# added by theirs\nmiddle: true\nalpha:\n  port: 80 # http\n  host: example.com\n",
+			wantConflicts: nil,
+		},
+		{
+			name: "양쪽에서 길이가 달라진 배열은 배열 전체의 충돌",
+			args: args{
+				base: "x: [a, b]\ny: 1\n",
+				lhs:  "x: [b]\ny: 1\n",
+				rhs:  "x: [a, b, c]\ny: 2\n",
+			},
+			want:          "x: [b]\ny: 2\n",
+			wantConflicts: []string{"x"},
+		},
+		{
+			name: "lhs 에서 배열 길이가 바뀌지 않은 경우 rhs 의 추가를 반영",
+			args: args{
+				base: "x: [a, b]\n",
+				lhs:  "x: [a, B]\n",
+				rhs:  "x: [a, b, c]\n",
+			},
+			want:          "x: [a, B, c]\n",
+			wantConflicts: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMerger(Markers)
+			got, err := m.Merge(parseNode(t, tt.args.base), parseNode(t, tt.args.lhs), parseNode(t, tt.args.rhs))
+			assert.NoError(t, err)
+
+			rendered, err := marshal(got.Merged)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(rendered))
+
+			var conflicts []string
+			for _, conflict := range got.Conflicts {
+				conflicts = append(conflicts, conflict.Key)
+			}
+			assert.Equal(t, tt.wantConflicts, conflicts)
+		})
+	}
+}
+
+func Test_merger_Render(t *testing.T) {
+	m := newTestMerger(Markers)
+	result, err := m.Merge(
+		parseNode(t, "app:\n  image: app:1\n  port: 80\n"),
+		parseNode(t, "app:\n  image: app:2\n  port: 80\n"),
+		parseNode(t, "app:\n  image:\n    tag: \"3\"\n  port: 8080\n"),
+	)
+	assert.NoError(t, err)
+
+	got, err := m.Render(result)
+	assert.NoError(t, err)
+	assert.Equal(t, `app:
+<<<<<<< ours
+  image: app:2
+=======
+  image:
+    tag: "3"
+>>>>>>> theirs
+  port: 8080
+`, string(got))
+}
@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/samber/lo"
+)
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Classify 는 두 OpenAPI 3 명세의 비교 결과를 하위 호환성을 깨는 변경(BREAKING_CHANGE)과 그렇지 않은 변경(NON_BREAKING_CHANGE)으로 분류합니다.
+// lhs 를 기존 명세, rhs 를 새 명세로 보며, 다음 변경을 호환되지 않는 변경으로 분류합니다.
+//   - 엔드포인트 제거
+//   - 필수 파라미터, 필수 요청 본문 추가 또는 기존 파라미터의 필수 전환
+//   - enum 값 제거
+//   - 응답 타입 변경, 응답 미디어 타입 제거
+//
+// 같은 enum 에서 발생한 결과는 enum 전체 값을 담은 하나의 결과로 합칩니다.
+// 2-way 비교 결과가 아닌 결과(스키마 위반, 3-way 결과 등)는 그대로 반환합니다.
+func Classify(results domain.ErrorResults, lhs map[string]any, rhs map[string]any) domain.ErrorResults {
+	pathKeys := pathItemKeys(lhs, rhs)
+	enums := make(map[string]bool)
+
+	classified := make(domain.ErrorResults, 0, len(results))
+	for _, result := range results {
+		switch result.ErrorCode {
+		case domain.ErrorKeyNotFound, domain.ErrorIndexNotFound, domain.ErrorTypeUnmatched, domain.ErrorValueUnmatched:
+		default:
+			classified = append(classified, result)
+			continue
+		}
+
+		if enumKey, ok := enumOf(result.Key); ok {
+			if enums[enumKey] {
+				continue
+			}
+			enums[enumKey] = true
+
+			classified = append(classified, classifyEnum(result, enumKey, lhs, rhs))
+			continue
+		}
+
+		code, message := classify(result, pathKeys, lhs, rhs)
+		classified = append(classified, domain.ClassifiedResult(result, code, message))
+	}
+
+	return classified
+}
+
+func classify(result domain.ErrorResult, pathKeys []string, lhs map[string]any, rhs map[string]any) (domain.ErrorCode, string) {
+	added, removed := result.FindNilSide() == "LHS", result.FindNilSide() == "RHS"
+	rhsKey := rhsPath(result, lhs, rhs)
+
+	pathKey, ok := lo.Find(pathKeys, func(pathKey string) bool {
+		return result.Key == pathKey || strings.HasPrefix(result.Key, pathKey+".")
+	})
+	if !ok {
+		return generic(added, removed)
+	}
+
+	endpoint := strings.TrimPrefix(pathKey, "paths.")
+	if result.Key == pathKey {
+		switch {
+		case removed:
+			return domain.ErrorBreakingChange, fmt.Sprintf("Endpoint removed: %s", endpoint)
+		case added:
+			return domain.ErrorNonBreakingChange, fmt.Sprintf("Endpoint added: %s", endpoint)
+		default:
+			return generic(added, removed)
+		}
+	}
+
+	segments := domain.SplitPath(strings.TrimPrefix(result.Key, pathKey+"."))
+	if lo.Contains(methods, segments[0]) {
+		endpoint = strings.ToUpper(segments[0]) + " " + endpoint
+		segments = segments[1:]
+
+		if len(segments) == 0 {
+			switch {
+			case removed:
+				return domain.ErrorBreakingChange, fmt.Sprintf("Endpoint removed: %s", endpoint)
+			case added:
+				return domain.ErrorNonBreakingChange, fmt.Sprintf("Endpoint added: %s", endpoint)
+			}
+		}
+	}
+
+	switch {
+	case matchSegments(segments, "parameters"):
+		parameters, _ := domain.Lookup(rhs, rhsKey)
+		list, _ := parameters.([]any)
+		if required, ok := lo.Find(list, isRequired); added && ok {
+			return domain.ErrorBreakingChange, fmt.Sprintf("Required parameter added: %s %s", parameterName(required), endpoint)
+		}
+	case matchSegments(segments, "parameters", "[*]"):
+		switch {
+		case added:
+			value, _ := domain.Lookup(rhs, rhsKey)
+			if isRequired(value) {
+				return domain.ErrorBreakingChange, fmt.Sprintf("Required parameter added: %s %s", parameterName(value), endpoint)
+			}
+			return domain.ErrorNonBreakingChange, fmt.Sprintf("Optional parameter added: %s %s", parameterName(value), endpoint)
+		case removed:
+			value, _ := domain.Lookup(lhs, result.Key)
+			return domain.ErrorNonBreakingChange, fmt.Sprintf("Parameter removed: %s %s", parameterName(value), endpoint)
+		}
+	case matchSegments(segments, "parameters", "[*]", "required"):
+		if becameRequired(result.Key, rhsKey, lhs, rhs) {
+			parameter, _ := domain.Lookup(rhs, strings.TrimSuffix(rhsKey, ".required"))
+			return domain.ErrorBreakingChange, fmt.Sprintf("Parameter became required: %s %s", parameterName(parameter), endpoint)
+		}
+	case matchSegments(segments, "requestBody"):
+		value, _ := domain.Lookup(rhs, rhsKey)
+		if added && isRequired(value) {
+			return domain.ErrorBreakingChange, fmt.Sprintf("Required request body added: %s", endpoint)
+		}
+	case matchSegments(segments, "requestBody", "required"):
+		if becameRequired(result.Key, rhsKey, lhs, rhs) {
+			return domain.ErrorBreakingChange, fmt.Sprintf("Request body became required: %s", endpoint)
+		}
+	case matchSegments(segments, "responses", "*", "content", "*"):
+		if removed {
+			mediaType := result.Key[strings.LastIndex(result.Key, ".content.")+len(".content."):]
+			return domain.ErrorBreakingChange, fmt.Sprintf("Response media type removed: %s %s", mediaType, endpoint)
+		}
+	case len(segments) > 0 && segments[0] == "responses" && lo.Contains(segments, "schema"):
+		if result.ErrorCode == domain.ErrorTypeUnmatched || segments[len(segments)-1] == "type" && !added && !removed {
+			return domain.ErrorBreakingChange, fmt.Sprintf("Response type changed: %s", endpoint)
+		}
+	}
+
+	return generic(added, removed)
+}
+
+func generic(added bool, removed bool) (domain.ErrorCode, string) {
+	switch {
+	case added:
+		return domain.ErrorNonBreakingChange, "Added"
+	case removed:
+		return domain.ErrorNonBreakingChange, "Removed"
+	default:
+		return domain.ErrorNonBreakingChange, "Changed"
+	}
+}
+
+// classifyEnum 은 enum 의 값이 제거되었거나 enum 이 새로 지정된 경우 호환되지 않는 변경으로 분류합니다.
+func classifyEnum(result domain.ErrorResult, enumKey string, lhs map[string]any, rhs map[string]any) domain.ErrorResult {
+	lhsEnum, _ := domain.Lookup(lhs, enumKey)
+	rhsEnum, _ := domain.Lookup(rhs, rhsPath(domain.ErrorResult{Key: enumKey}, lhs, rhs))
+
+	result.Key = enumKey
+	result.LHS = domain.NewYAMLEntry(lhsEnum)
+	result.RHS = domain.NewYAMLEntry(rhsEnum)
+
+	lhsValues, lhsOK := lhsEnum.([]any)
+	rhsValues, rhsOK := rhsEnum.([]any)
+	switch {
+	case !rhsOK:
+		return domain.ClassifiedResult(result, domain.ErrorNonBreakingChange, "Enum removed")
+	case !lhsOK:
+		return domain.ClassifiedResult(result, domain.ErrorBreakingChange, "Enum added")
+	}
+
+	removed := lo.Filter(lhsValues, func(value any, _ int) bool {
+		return !lo.ContainsBy(rhsValues, func(other any) bool {
+			return fmt.Sprint(other) == fmt.Sprint(value)
+		})
+	})
+	if len(removed) > 0 {
+		return domain.ClassifiedResult(result, domain.ErrorBreakingChange, fmt.Sprintf("Enum narrowed: removed %v", removed))
+	}
+
+	return domain.ClassifiedResult(result, domain.ErrorNonBreakingChange, "Enum widened")
+}
+
+// enumOf 는 키가 enum 이나 enum 의 요소를 가리키는 경우 enum 의 경로를 반환합니다.
+func enumOf(key string) (string, bool) {
+	if strings.HasSuffix(key, ".enum") {
+		return key, true
+	}
+
+	if idx := strings.LastIndex(key, ".enum["); idx >= 0 && !strings.Contains(key[idx+len(".enum["):], ".") {
+		return key[:idx+len(".enum")], true
+	}
+
+	return "", false
+}
+
+// pathItemKeys 는 양쪽 명세의 paths 아래 경로 키를 긴 것부터 정렬해 반환합니다.
+func pathItemKeys(lhs map[string]any, rhs map[string]any) []string {
+	set := make(map[string]bool)
+	for _, document := range []map[string]any{lhs, rhs} {
+		paths, _ := document["paths"].(map[string]any)
+		for path := range paths {
+			set[domain.MapKey("paths", path)] = true
+		}
+	}
+
+	keys := lo.Keys(set)
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func matchSegments(segments []string, pattern ...string) bool {
+	return domain.PathPattern(pattern).MatchSegments(segments)
+}
+
+func isRequired(value any) bool {
+	m, _ := value.(map[string]any)
+	required, _ := m["required"].(bool)
+	return required
+}
+
+func becameRequired(lhsKey string, rhsKey string, lhs map[string]any, rhs map[string]any) bool {
+	lhsVal, _ := domain.Lookup(lhs, lhsKey)
+	rhsVal, _ := domain.Lookup(rhs, rhsKey)
+	return rhsVal == true && lhsVal != true
+}
+
+// rhsPath 는 결과 경로를 rhs 문서에서 같은 값을 가리키는 경로로 바꿉니다.
+// openapi 프로파일은 parameters 의 요소를 name 으로 짝지으므로 결과 경로의 인덱스는 lhs 기준이며,
+// rhs 에서는 name 과 in 이 같은 파라미터의 인덱스를 사용합니다. rhs 에만 있는 요소의 인덱스는 이미 rhs 기준입니다.
+func rhsPath(result domain.ErrorResult, lhs map[string]any, rhs map[string]any) string {
+	segments := domain.SplitPath(result.Key)
+	lhsKey, rhsKey := "", ""
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "[") {
+			lhsKey, rhsKey = domain.MapKey(lhsKey, segment), domain.MapKey(rhsKey, segment)
+			continue
+		}
+
+		last := i == len(segments)-1
+		if i == 0 || segments[i-1] != "parameters" || (last && result.FindNilSide() == "LHS") {
+			lhsKey, rhsKey = lhsKey+segment, rhsKey+segment
+			continue
+		}
+
+		lhsParameter, _ := domain.Lookup(lhs, lhsKey+segment)
+		rhsParameters, _ := domain.Lookup(rhs, rhsKey)
+		list, _ := rhsParameters.([]any)
+		_, idx, ok := lo.FindIndexOf(list, func(parameter any) bool {
+			return sameParameter(lhsParameter, parameter)
+		})
+		lhsKey += segment
+		if !ok {
+			rhsKey += segment
+			continue
+		}
+		rhsKey = domain.SliceKey(rhsKey, idx)
+	}
+
+	return rhsKey
+}
+
+func sameParameter(lhs any, rhs any) bool {
+	lhsMap, lhsOk := lhs.(map[string]any)
+	rhsMap, rhsOk := rhs.(map[string]any)
+	return lhsOk && rhsOk && lhsMap["name"] == rhsMap["name"] && lhsMap["in"] == rhsMap["in"]
+}
+
+func parameterName(value any) string {
+	m, _ := value.(map[string]any)
+	return fmt.Sprintf("%v (%v)", m["name"], m["in"])
+}
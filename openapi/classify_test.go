@@ -0,0 +1,138 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	lhs := map[string]any{
+		"info": map[string]any{"version": "1"},
+		"paths": map[string]any{
+			"/pets": map[string]any{
+				"get": map[string]any{
+					"parameters": []any{
+						map[string]any{"name": "limit", "in": "query"},
+						map[string]any{"name": "tag", "in": "query"},
+					},
+					"responses": map[string]any{
+						"200": map[string]any{"content": map[string]any{
+							"application/json": map[string]any{"schema": map[string]any{"type": "array"}},
+						}},
+					},
+				},
+				"delete": map[string]any{},
+			},
+			"/pets/{id}": map[string]any{"get": map[string]any{
+				"parameters": []any{map[string]any{"name": "status", "in": "query", "schema": map[string]any{"enum": []any{"available", "sold"}}}},
+			}},
+		},
+	}
+	rhs := map[string]any{
+		"info": map[string]any{"version": "2"},
+		"paths": map[string]any{
+			"/pets": map[string]any{
+				"get": map[string]any{
+					"parameters": []any{
+						map[string]any{"name": "limit", "in": "query", "required": true},
+						map[string]any{"name": "owner", "in": "query"},
+					},
+					"responses": map[string]any{
+						"200": map[string]any{"content": map[string]any{
+							"application/json": map[string]any{"schema": map[string]any{"type": "object"}},
+						}},
+					},
+				},
+			},
+			"/pets/{id}": map[string]any{"get": map[string]any{
+				"parameters": []any{map[string]any{"name": "status", "in": "query", "schema": map[string]any{"enum": []any{"available"}}}},
+			}},
+		},
+	}
+	results := domain.ErrorResults{
+		domain.KeyNotFoundResult("paths./pets.delete", map[string]any{}, nil),
+		domain.KeyNotFoundResult("paths./pets.get.parameters[0].required", nil, true),
+		domain.IndexNotFoundResult("paths./pets.get.parameters[1]", lhs["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)[1], nil),
+		domain.IndexNotFoundResult("paths./pets.get.parameters[1]", nil, rhs["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)[1]),
+		domain.ValueUnmatchedResult("paths./pets.get.responses.200.content.application/json.schema.type", "array", "object"),
+		domain.ValueUnmatchedResult("paths./pets/{id}.get.parameters[0].schema.enum[1]", "sold", nil),
+		domain.IndexNotFoundResult("paths./pets/{id}.get.parameters[0].schema.enum[1]", "sold", nil),
+		domain.ValueUnmatchedResult("info.version", "1", "2"),
+		{Key: "info.title", ErrorCode: domain.ErrorSchemaViolated},
+	}
+
+	got := Classify(results, lhs, rhs)
+
+	type classified struct {
+		key     string
+		code    domain.ErrorCode
+		message string
+	}
+	want := []classified{
+		{key: "paths./pets.delete", code: domain.ErrorBreakingChange, message: "Endpoint removed: DELETE /pets"},
+		{key: "paths./pets.get.parameters[0].required", code: domain.ErrorBreakingChange, message: "Parameter became required: limit (query) GET /pets"},
+		{key: "paths./pets.get.parameters[1]", code: domain.ErrorNonBreakingChange, message: "Parameter removed: tag (query) GET /pets"},
+		{key: "paths./pets.get.parameters[1]", code: domain.ErrorNonBreakingChange, message: "Optional parameter added: owner (query) GET /pets"},
+		{key: "paths./pets.get.responses.200.content.application/json.schema.type", code: domain.ErrorBreakingChange, message: "Response type changed: GET /pets"},
+		{key: "paths./pets/{id}.get.parameters[0].schema.enum", code: domain.ErrorBreakingChange, message: "Enum narrowed: removed [sold]"},
+		{key: "info.version", code: domain.ErrorNonBreakingChange, message: "Changed"},
+		{key: "info.title", code: domain.ErrorSchemaViolated},
+	}
+
+	actual := make([]classified, 0, len(got))
+	for _, result := range got {
+		actual = append(actual, classified{key: result.Key, code: result.ErrorCode, message: result.Message})
+	}
+	assert.Equal(t, want, actual)
+	assert.Equal(t, domain.SeverityError, got[0].Severity)
+}
+
+func TestClassify_ReorderedParameters(t *testing.T) {
+	lhs := map[string]any{"paths": map[string]any{"/pets": map[string]any{"get": map[string]any{
+		"parameters": []any{
+			map[string]any{"name": "a", "in": "query"},
+			map[string]any{"name": "b", "in": "query", "schema": map[string]any{"enum": []any{"x", "y"}}},
+		},
+	}}}}
+	rhs := map[string]any{"paths": map[string]any{"/pets": map[string]any{"get": map[string]any{
+		"parameters": []any{
+			map[string]any{"name": "b", "in": "query", "schema": map[string]any{"enum": []any{"x"}}},
+			map[string]any{"name": "a", "in": "query", "required": true},
+		},
+	}}}}
+
+	tests := []struct {
+		name        string
+		result      domain.ErrorResult
+		wantKey     string
+		wantCode    domain.ErrorCode
+		wantMessage string
+	}{
+		{
+			name:        "순서가 바뀐 파라미터의 필수 전환",
+			result:      domain.KeyNotFoundResult("paths./pets.get.parameters[0].required", nil, true),
+			wantKey:     "paths./pets.get.parameters[0].required",
+			wantCode:    domain.ErrorBreakingChange,
+			wantMessage: "Parameter became required: a (query) GET /pets",
+		},
+		{
+			name:        "순서가 바뀐 파라미터의 enum 축소",
+			result:      domain.IndexNotFoundResult("paths./pets.get.parameters[1].schema.enum[1]", "y", nil),
+			wantKey:     "paths./pets.get.parameters[1].schema.enum",
+			wantCode:    domain.ErrorBreakingChange,
+			wantMessage: "Enum narrowed: removed [y]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Classify(domain.ErrorResults{tt.result}, lhs, rhs)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.wantKey, got[0].Key)
+			assert.Equal(t, tt.wantCode, got[0].ErrorCode)
+			assert.Equal(t, tt.wantMessage, got[0].Message)
+		})
+	}
+}
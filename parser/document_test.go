@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parser_parseDocumentSet(t *testing.T) {
+	p := parser{config: Config{DocumentKey: []string{"apiVersion", "kind", "metadata.namespace", "metadata.name"}}}
+
+	content := []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: web
+---
+`)
+
+	got, err := p.parseDocumentSet("manifest.yaml", content)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"apps/v1/Deployment/web/web", "v1/Namespace/web"}, sortedMapKeys(got.values))
+
+	_, err = p.parseDocumentSet("manifest.yaml", append(content, content...))
+	assert.EqualError(t, err, "manifest.yaml: duplicate document v1/Namespace/web")
+}
+
+func sortedMapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+// mergeValues 는 Helm values 병합 규칙에 따라 src 를 dst 위에 덮어씁니다.
+// 맵은 재귀적으로 병합되고, 배열과 스칼라 값은 교체되며, null 값은 키를 삭제합니다.
+// origins 에는 각 경로의 값을 최종적으로 제공한 파일이 기록됩니다.
+func mergeValues(dst map[string]any, src map[string]any, parent string, source string, origins domain.Origins) map[string]any {
+	if dst == nil {
+		dst = make(map[string]any)
+	}
+
+	for key, srcVal := range src {
+		nextKey := domain.MapKey(parent, key)
+
+		if srcVal == nil {
+			delete(dst, key)
+			clearOrigins(origins, nextKey)
+			continue
+		}
+
+		srcMap, srcIsMap := srcVal.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			dst[key] = mergeValues(dstMap, srcMap, nextKey, source, origins)
+			continue
+		}
+
+		clearOrigins(origins, nextKey)
+		if srcIsMap {
+			dst[key] = mergeValues(nil, srcMap, nextKey, source, origins)
+		} else {
+			dst[key] = srcVal
+		}
+		setOrigin(origins, nextKey, source)
+	}
+
+	return dst
+}
+
+// recordOrigins 는 values 의 모든 경로에 대해 source 를 기록합니다.
+func recordOrigins(values map[string]any, parent string, source string, origins domain.Origins) {
+	for key, val := range values {
+		nextKey := domain.MapKey(parent, key)
+		setOrigin(origins, nextKey, source)
+
+		if child, ok := val.(map[string]any); ok {
+			recordOrigins(child, nextKey, source, origins)
+		}
+	}
+}
+
+// recordFragments 는 $ref, !include 로 다른 파일에서 온 경로의 출처를 덮어씁니다.
+// 상위 경로부터 기록하여 하위 조각의 출처가 상위 조각의 출처에 지워지지 않도록 합니다.
+func recordFragments(fragments domain.Origins, origins domain.Origins) {
+	keys := make([]string, 0, len(fragments))
+	for key := range fragments {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return len(keys[i]) < len(keys[j])
+	})
+
+	for _, key := range keys {
+		clearOrigins(origins, key)
+		setOrigin(origins, key, fragments[key])
+	}
+}
+
+func setOrigin(origins domain.Origins, key string, source string) {
+	if origins == nil {
+		return
+	}
+
+	origins[key] = source
+}
+
+// clearOrigins 는 key 와 그 하위 경로에 기록된 출처를 제거합니다.
+func clearOrigins(origins domain.Origins, key string) {
+	for path := range origins {
+		if path == key || isDescendant(path, key) {
+			delete(origins, path)
+		}
+	}
+}
+
+func isDescendant(path string, ancestor string) bool {
+	if ancestor == "" {
+		return path != ""
+	}
+
+	if len(path) <= len(ancestor) || path[:len(ancestor)] != ancestor {
+		return false
+	}
+
+	next := path[len(ancestor)]
+	return next == '.' || next == '['
+}
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergeValues(t *testing.T) {
+	type args struct {
+		dst map[string]any
+		src map[string]any
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        map[string]any
+		wantOrigins domain.Origins
+	}{
+		{
+			name: "맵은 재귀적으로 병합",
+			args: args{
+				dst: map[string]any{"db": map[string]any{"host": "a", "port": 5432}},
+				src: map[string]any{"db": map[string]any{"host": "b"}},
+			},
+			want:        map[string]any{"db": map[string]any{"host": "b", "port": 5432}},
+			wantOrigins: domain.Origins{"db": "base.yaml", "db.host": "prod.yaml", "db.port": "base.yaml"},
+		},
+		{
+			name: "배열은 교체",
+			args: args{
+				dst: map[string]any{"hosts": []any{"a", "b"}},
+				src: map[string]any{"hosts": []any{"c"}},
+			},
+			want:        map[string]any{"hosts": []any{"c"}},
+			wantOrigins: domain.Origins{"hosts": "prod.yaml"},
+		},
+		{
+			name: "null 은 키를 삭제",
+			args: args{
+				dst: map[string]any{"debug": map[string]any{"level": 1}, "name": "a"},
+				src: map[string]any{"debug": nil},
+			},
+			want:        map[string]any{"name": "a"},
+			wantOrigins: domain.Origins{"name": "base.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origins := make(domain.Origins)
+			recordOrigins(tt.args.dst, "", "base.yaml", origins)
+
+			got := mergeValues(tt.args.dst, tt.args.src, "", "prod.yaml", origins)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOrigins, origins)
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package parser
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
 
@@ -10,6 +15,8 @@ import (
 
 type Parser interface {
 	Parse() (domain.ParserResult, error)
+	ParseDocuments(inputs []string) ([]domain.Document, error)
+	ParseNode(path string) (*yaml.Node, error)
 }
 
 type parser struct {
@@ -23,31 +30,273 @@ func New(config Config) Parser {
 type Config struct {
 	LHSPath string
 	RHSPath string
+	// BasePath 는 3-way 비교에서 사용할 공통 조상 파일 경로입니다.
+	BasePath string
+
+	// LHSLayers, RHSLayers 는 각 경로 위에 순서대로 병합할 values 파일입니다.
+	LHSLayers []string
+	RHSLayers []string
+
+	// LHSPatches, RHSPatches 는 레이어 병합 후 적용할 strategic merge patch 파일입니다.
+	LHSPatches []string
+	RHSPatches []string
+	// MergeKeys 는 strategic merge 시 배열 필드별로 요소를 식별하는 키입니다.
+	MergeKeys map[string]string
+
+	// AgeKeyFile 은 SOPS 로 암호화된 값을 복호화할 age 키 파일 경로입니다.
+	AgeKeyFile string
+
+	// DocumentKey 는 여러 문서로 구성된 파일에서 각 문서를 식별하는 필드 경로입니다.
+	// 지정된 경우 파일의 모든 문서를 식별자를 키로 하는 하나의 맵으로 읽습니다.
+	DocumentKey []string
+
+	// ResolveRefs 는 파일을 읽을 때 $ref 와 !include 를 참조 대상 값으로 치환할지 여부입니다.
+	ResolveRefs bool
 }
 
 func (p parser) Parse() (domain.ParserResult, error) {
-	var (
-		lhs map[string]any
-		rhs map[string]any
-	)
-
-	lhsFile, err := os.ReadFile(p.config.LHSPath)
+	lhs, err := p.parseLayers(p.config.LHSPath, p.config.LHSLayers, p.config.LHSPatches)
 	if err != nil {
 		return domain.ParserResult{}, err
 	}
 
-	if err = yaml.Unmarshal(lhsFile, &lhs); err != nil {
+	rhs, err := p.parseLayers(p.config.RHSPath, p.config.RHSLayers, p.config.RHSPatches)
+	if err != nil {
 		return domain.ParserResult{}, err
 	}
 
-	rhsFile, err := os.ReadFile(p.config.RHSPath)
+	result := domain.ParserResult{
+		LHS:          lhs.values,
+		RHS:          rhs.values,
+		LHSOrigins:   lhs.origins,
+		RHSOrigins:   rhs.origins,
+		LHSEncrypted: lhs.encrypted,
+		RHSEncrypted: rhs.encrypted,
+	}
+
+	if p.config.BasePath != "" {
+		base, err := p.parseLayers(p.config.BasePath, nil, nil)
+		if err != nil {
+			return domain.ParserResult{}, err
+		}
+
+		result.Base = base.values
+		result.BaseEncrypted = base.encrypted
+	}
+
+	return result, nil
+}
+
+// ParseDocuments 는 "alias=path" 형식의 입력을 각각 파싱합니다. alias 를 생략한 경우 경로를 alias 로 사용합니다.
+func (p parser) ParseDocuments(inputs []string) ([]domain.Document, error) {
+	documents := make([]domain.Document, 0, len(inputs))
+	for _, input := range inputs {
+		alias, path, ok := strings.Cut(input, "=")
+		if !ok {
+			alias, path = input, input
+		}
+
+		file, err := p.parseFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, domain.Document{
+			Alias:     alias,
+			Path:      path,
+			Values:    file.values,
+			Encrypted: file.encrypted,
+		})
+	}
+
+	return documents, nil
+}
+
+// ParseNode 는 키 순서와 주석을 유지하도록 파일을 yaml.Node 로 읽습니다.
+// 값을 다시 기록하는 용도이므로 SOPS 로 암호화된 파일은 지원하지 않습니다.
+func (p parser) ParseNode(path string) (*yaml.Node, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(file, &node); err != nil {
+		return nil, err
+	}
+
+	var values map[string]any
+	if err = node.Decode(&values); err != nil {
+		return nil, err
+	}
+	if isSOPSDocument(values) {
+		return nil, fmt.Errorf("%s: sops-encrypted files are not supported", path)
+	}
+
+	return &node, nil
+}
+
+// document 는 한쪽 입력의 모든 파일을 병합한 결과입니다.
+// 파일 하나를 읽은 결과인 경우 origins 에는 $ref, !include 로 다른 파일에서 온 경로만 기록됩니다.
+type document struct {
+	values    map[string]any
+	origins   domain.Origins
+	encrypted []string
+}
+
+func (p parser) parseLayers(path string, layers []string, patches []string) (document, error) {
+	result, err := p.parseFile(path)
 	if err != nil {
-		return domain.ParserResult{}, err
+		return document{}, err
 	}
 
-	if err = yaml.Unmarshal(rhsFile, &rhs); err != nil {
-		return domain.ParserResult{}, err
+	if len(layers) == 0 && len(patches) == 0 && len(result.origins) == 0 {
+		return result, nil
+	}
+
+	fragments := result.origins
+	result.origins = make(domain.Origins)
+	recordOrigins(result.values, "", path, result.origins)
+	recordFragments(fragments, result.origins)
+
+	for _, layer := range layers {
+		file, err := p.parseFile(layer)
+		if err != nil {
+			return document{}, err
+		}
+
+		result.values = mergeValues(result.values, file.values, "", layer, result.origins)
+		recordFragments(file.origins, result.origins)
+		result.encrypted = append(result.encrypted, file.encrypted...)
+	}
+
+	for _, patch := range patches {
+		file, err := p.parseFile(patch)
+		if err != nil {
+			return document{}, err
+		}
+
+		result.values = p.strategicMerge(result.values, file.values, "", patch, result.origins)
+		recordFragments(file.origins, result.origins)
+		result.encrypted = append(result.encrypted, file.encrypted...)
+	}
+
+	return result, nil
+}
+
+func (p parser) parseFile(path string) (document, error) {
+	var result map[string]any
+
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return document{}, err
+	}
+
+	if len(p.config.DocumentKey) > 0 {
+		return p.parseDocumentSet(path, file)
+	}
+
+	if !p.config.ResolveRefs {
+		if err = yaml.Unmarshal(file, &result); err != nil {
+			return document{}, err
+		}
+
+		return p.decrypt(result)
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(file, &node); err != nil {
+		return document{}, err
+	}
+
+	resolved, fragments, err := resolveReferences(path, &node, "")
+	if err != nil {
+		return document{}, err
+	}
+	if err = resolved.Decode(&result); err != nil {
+		return document{}, err
+	}
+
+	decrypted, err := p.decrypt(result)
+	decrypted.origins = fragments
+	return decrypted, err
+}
+
+func (p parser) decrypt(values map[string]any) (document, error) {
+	if !isSOPSDocument(values) {
+		return document{values: values}, nil
+	}
+
+	values, encrypted, err := p.resolveSOPS(values)
+	return document{values: values, encrypted: encrypted}, err
+}
+
+// parseDocumentSet 은 파일의 모든 문서를 DocumentKey 필드 값으로 만든 식별자를 키로 하는 맵으로 읽습니다.
+// SOPS 로 암호화된 문서는 문서별로 복호화하고, 암호화된 경로는 식별자 아래의 경로로 기록합니다.
+func (p parser) parseDocumentSet(path string, file []byte) (document, error) {
+	result := document{values: make(map[string]any), origins: make(domain.Origins)}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(file))
+	for idx := 0; ; idx++ {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return document{}, err
+		}
+
+		var fragments domain.Origins
+		if p.config.ResolveRefs {
+			resolved, origins, err := resolveReferences(path, &node, "")
+			if err != nil {
+				return document{}, err
+			}
+			node, fragments = *resolved, origins
+		}
+
+		var values map[string]any
+		if err := node.Decode(&values); err != nil {
+			return document{}, err
+		}
+		if values == nil {
+			continue
+		}
+
+		decrypted, err := p.decrypt(values)
+		if err != nil {
+			return document{}, fmt.Errorf("%s: document %d: %w", path, idx, err)
+		}
+		doc := decrypted.values
+
+		id := p.documentID(doc)
+		if id == "" {
+			return document{}, fmt.Errorf("%s: document %d has no identifying fields", path, idx)
+		}
+		if _, ok := result.values[id]; ok {
+			return document{}, fmt.Errorf("%s: duplicate document %s", path, id)
+		}
+		result.values[id] = doc
+		for key, source := range fragments {
+			result.origins[domain.MapKey(id, key)] = source
+		}
+		for _, key := range decrypted.encrypted {
+			result.encrypted = append(result.encrypted, domain.MapKey(id, key))
+		}
+	}
+
+	return result, nil
+}
+
+func (p parser) documentID(doc map[string]any) string {
+	var parts []string
+	for _, field := range p.config.DocumentKey {
+		value, ok := domain.Lookup(doc, field)
+		if !ok || value == nil || value == "" {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%v", value))
 	}
 
-	return domain.ParserResult{LHS: lhs, RHS: rhs}, nil
+	return strings.Join(parts, "/")
 }
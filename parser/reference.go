@@ -0,0 +1,278 @@
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	refKey     = "$ref"
+	includeTag = "!include"
+)
+
+// resolver 는 한 입력 파일의 $ref 와 !include 를 참조 대상 값으로 치환합니다.
+type resolver struct {
+	// files 는 읽은 파일의 루트 노드입니다. 같은 파일을 여러 번 참조해도 한 번만 읽습니다.
+	files map[string]*yaml.Node
+	// resolving 은 치환 중인 참조로, 순환 참조를 확인합니다.
+	resolving []string
+	// fragments 는 다른 파일의 값으로 치환된 경로와 해당 파일입니다.
+	fragments domain.Origins
+}
+
+// resolveReferences 는 path 파일의 node 에서 참조를 치환한 새 노드와 다른 파일에서 온 경로의 출처를 반환합니다.
+// $ref 는 JSON Reference 형식("other.yaml#/a/b", "#/a/b")을, !include 는 파일 경로(fragment 지정 가능)를 값으로 받으며,
+// 상대 경로는 참조를 포함한 파일의 디렉터리를 기준으로 합니다. URL 을 가리키는 $ref 는 치환하지 않습니다.
+// 순환하는 $ref 는 치환하지 않고 그대로 두며(ex. 재귀 스키마), 순환하는 !include 는 에러를 반환합니다.
+// key 는 node 의 경로로, 출처를 기록할 때 앞에 붙입니다.
+func resolveReferences(path string, node *yaml.Node, key string) (*yaml.Node, domain.Origins, error) {
+	r := resolver{
+		files:     map[string]*yaml.Node{filepath.Clean(path): node},
+		fragments: make(domain.Origins),
+	}
+
+	resolved, err := r.resolve(filepath.Clean(path), node, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resolved, r.fragments, nil
+}
+
+func (r *resolver) resolve(file string, node *yaml.Node, key string) (*yaml.Node, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return node, nil
+		}
+
+		content, err := r.resolve(file, node.Content[0], key)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved := *node
+		resolved.Content = []*yaml.Node{content}
+		return &resolved, nil
+	case yaml.AliasNode:
+		return r.resolve(file, node.Alias, key)
+	case yaml.ScalarNode:
+		if node.Tag != includeTag {
+			return node, nil
+		}
+
+		return r.follow(file, node.Value, key, true)
+	case yaml.SequenceNode:
+		resolved := *node
+		resolved.Content = make([]*yaml.Node, len(node.Content))
+		for idx, elem := range node.Content {
+			child, err := r.resolve(file, elem, domain.SliceKey(key, idx))
+			if err != nil {
+				return nil, err
+			}
+			resolved.Content[idx] = child
+		}
+		return &resolved, nil
+	case yaml.MappingNode:
+		if ref, ok := refOf(node); ok && !strings.Contains(ref, "://") {
+			return r.resolveRef(file, node, ref, key)
+		}
+
+		return r.resolveMapping(file, node, key)
+	default:
+		return node, nil
+	}
+}
+
+func (r *resolver) resolveMapping(file string, node *yaml.Node, key string) (*yaml.Node, error) {
+	resolved := *node
+	resolved.Content = make([]*yaml.Node, len(node.Content))
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		child, err := r.resolve(file, node.Content[idx+1], domain.MapKey(key, node.Content[idx].Value))
+		if err != nil {
+			return nil, err
+		}
+		resolved.Content[idx], resolved.Content[idx+1] = node.Content[idx], child
+	}
+
+	return &resolved, nil
+}
+
+// resolveRef 는 $ref 를 가진 맵을 참조 대상으로 치환합니다. $ref 와 함께 지정된 키는 대상 값 위에 덮어씁니다.
+func (r *resolver) resolveRef(file string, node *yaml.Node, ref string, key string) (*yaml.Node, error) {
+	target, err := r.follow(file, ref, key, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(node.Content) == 2 || target.Kind != yaml.MappingNode {
+		return target, nil
+	}
+
+	merged := *target
+	merged.Content = append([]*yaml.Node(nil), target.Content...)
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		name := node.Content[idx].Value
+		if name == refKey {
+			continue
+		}
+
+		value, err := r.resolve(file, node.Content[idx+1], domain.MapKey(key, name))
+		if err != nil {
+			return nil, err
+		}
+
+		replaced := false
+		for i := 0; i+1 < len(merged.Content); i += 2 {
+			if merged.Content[i].Value == name {
+				merged.Content[i+1] = value
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged.Content = append(merged.Content, node.Content[idx], value)
+		}
+		if _, ok := r.fragments[key]; ok {
+			r.fragments[domain.MapKey(key, name)] = file
+		}
+	}
+
+	return &merged, nil
+}
+
+// follow 는 "path#/pointer" 형식의 참조가 가리키는 노드를 찾아 그 안의 참조까지 치환합니다.
+// 순환하는 $ref 는 치환하지 않은 $ref 노드를, 순환하는 !include 는 에러를 반환합니다.
+func (r *resolver) follow(file string, ref string, key string, include bool) (*yaml.Node, error) {
+	location, fragment, _ := strings.Cut(ref, "#")
+
+	target := file
+	if location != "" {
+		target = filepath.Clean(filepath.Join(filepath.Dir(file), location))
+	}
+
+	id := target + "#" + fragment
+	for _, resolving := range r.resolving {
+		if resolving != id {
+			continue
+		}
+
+		if include {
+			return nil, fmt.Errorf("circular include: %s", strings.Join(append(r.resolving, id), " -> "))
+		}
+
+		return refNode(ref), nil
+	}
+
+	root, err := r.load(target)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := pointerNode(root, fragment)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", file, ref, err)
+	}
+
+	if target != file {
+		clearOrigins(r.fragments, key)
+		r.fragments[key] = target
+	}
+
+	r.resolving = append(r.resolving, id)
+	resolved, err := r.resolve(target, node, key)
+	r.resolving = r.resolving[:len(r.resolving)-1]
+
+	return resolved, err
+}
+
+func (r *resolver) load(path string) (*yaml.Node, error) {
+	if node, ok := r.files[path]; ok {
+		return node, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(content, &node); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	r.files[path] = &node
+	return &node, nil
+}
+
+// pointerNode 는 "/a/b~1c/0" 형식의 JSON Pointer 가 가리키는 노드를 찾습니다.
+func pointerNode(root *yaml.Node, fragment string) (*yaml.Node, error) {
+	current := root
+	if current.Kind == yaml.DocumentNode && len(current.Content) > 0 {
+		current = current.Content[0]
+	}
+
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, err
+	}
+	if fragment == "" || fragment == "/" {
+		return current, nil
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		if current.Kind == yaml.AliasNode {
+			current = current.Alias
+		}
+
+		var next *yaml.Node
+		switch current.Kind {
+		case yaml.MappingNode:
+			for idx := 0; idx+1 < len(current.Content); idx += 2 {
+				if current.Content[idx].Value == token {
+					next = current.Content[idx+1]
+				}
+			}
+		case yaml.SequenceNode:
+			if idx, err := strconv.Atoi(token); err == nil && idx >= 0 && idx < len(current.Content) {
+				next = current.Content[idx]
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("reference target not found")
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+func refOf(node *yaml.Node) (string, bool) {
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == refKey && node.Content[idx+1].Kind == yaml.ScalarNode {
+			return node.Content[idx+1].Value, true
+		}
+	}
+
+	return "", false
+}
+
+// refNode 는 치환하지 않은 $ref 를 나타내는 노드를 만듭니다.
+func refNode(ref string) *yaml.Node {
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: refKey},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: ref},
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func Test_parser_parseFile_resolveRefs(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		want        map[string]any
+		wantOrigins map[string]string
+		wantErr     string
+	}{
+		{
+			name: "!include 와 다른 파일의 $ref",
+			files: map[string]string{
+				"app.yaml":            "database: !include common/db.yaml\ncache:\n  $ref: common/cache.yaml#/redis\n  ttl: 30\n",
+				"common/db.yaml":      "host: db\nreplica: !include replica.yaml\n",
+				"common/replica.yaml": "host: replica\n",
+				"common/cache.yaml":   "redis:\n  host: redis\n  ttl: 10\n",
+			},
+			want: map[string]any{
+				"database": map[string]any{"host": "db", "replica": map[string]any{"host": "replica"}},
+				"cache":    map[string]any{"host": "redis", "ttl": 30},
+			},
+			wantOrigins: map[string]string{
+				"database":         "common/db.yaml",
+				"database.replica": "common/replica.yaml",
+				"cache":            "common/cache.yaml",
+				"cache.ttl":        "app.yaml",
+			},
+		},
+		{
+			name: "순환하는 $ref 는 그대로 둠",
+			files: map[string]string{
+				"app.yaml": "node:\n  type: object\n  child:\n    $ref: \"#/node\"\n",
+			},
+			want: map[string]any{
+				"node": map[string]any{"type": "object", "child": map[string]any{"type": "object", "child": map[string]any{"$ref": "#/node"}}},
+			},
+			wantOrigins: map[string]string{},
+		},
+		{
+			name: "같은 파일의 $ref 와 함께 지정된 키는 대상 값 위에 덮어씀",
+			files: map[string]string{
+				"app.yaml": `paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/Id"
+      schema:
+        $ref: "#/components/schemas/Pet"
+        description: one pet
+components:
+  parameters:
+    Id: {name: id, in: path}
+  schemas:
+    Pet:
+      type: object
+`,
+			},
+			want: map[string]any{
+				"paths": map[string]any{
+					"/pets/{id}": map[string]any{
+						"get": map[string]any{
+							"parameters": []any{map[string]any{"name": "id", "in": "path"}},
+							"schema":     map[string]any{"type": "object", "description": "one pet"},
+						},
+					},
+				},
+				"components": map[string]any{
+					"parameters": map[string]any{"Id": map[string]any{"name": "id", "in": "path"}},
+					"schemas":    map[string]any{"Pet": map[string]any{"type": "object"}},
+				},
+			},
+			wantOrigins: map[string]string{},
+		},
+		{
+			name: "순환하는 !include 는 에러",
+			files: map[string]string{
+				"app.yaml": "a: !include a.yaml\n",
+				"a.yaml":   "b: !include app.yaml\n",
+			},
+			wantErr: "circular include",
+		},
+		{
+			name: "존재하지 않는 대상",
+			files: map[string]string{
+				"app.yaml": "a:\n  $ref: \"#/missing\"\n",
+			},
+			wantErr: "reference target not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			p := parser{config: Config{ResolveRefs: true}}
+
+			got, err := p.parseFile(filepath.Join(dir, "app.yaml"))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.values)
+
+			wantOrigins := make(domain.Origins)
+			for key, name := range tt.wantOrigins {
+				wantOrigins[key] = filepath.Join(dir, name)
+			}
+			assert.Equal(t, wantOrigins, got.origins)
+		})
+	}
+}
+
+func Test_parser_parseLayers_resolveRefs(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"app.yaml": "name: app\ndatabase: !include db.yaml\n",
+		"db.yaml":  "host: db\nport: 5432\n",
+	})
+	p := parser{config: Config{ResolveRefs: true}}
+
+	got, err := p.parseLayers(filepath.Join(dir, "app.yaml"), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "db.yaml"), got.origins.Lookup("database.port"))
+	assert.Equal(t, filepath.Join(dir, "app.yaml"), got.origins.Lookup("name"))
+}
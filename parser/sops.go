@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+const (
+	sopsMetadataKey = "sops"
+	encryptedMarker = "[ENCRYPTED]"
+)
+
+// encryptedValuePattern 은 SOPS 로 암호화된 값의 형식입니다.
+// ex. ENC[AES256_GCM,data:...,iv:...,tag:...,type:str]
+var encryptedValuePattern = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+type encryptedValue struct {
+	data      []byte
+	iv        []byte
+	tag       []byte
+	valueType string
+}
+
+func isSOPSDocument(document map[string]any) bool {
+	metadata, ok := document[sopsMetadataKey].(map[string]any)
+	if !ok {
+		return false
+	}
+
+	_, hasMAC := metadata["mac"]
+	return hasMAC
+}
+
+// resolveSOPS 는 SOPS 메타데이터를 제거하고 암호화된 값을 처리합니다.
+// age 키 파일이 지정된 경우 값을 복호화하고, 그렇지 않은 경우 암호문 변경이 차이로 보고되지 않도록
+// 모든 암호화된 값을 같은 마스킹 값으로 교체합니다. 암호화되어 있던 값의 경로를 함께 반환합니다.
+func (p parser) resolveSOPS(document map[string]any) (map[string]any, []string, error) {
+	metadata, _ := document[sopsMetadataKey].(map[string]any)
+	delete(document, sopsMetadataKey)
+
+	var dataKey []byte
+	if p.config.AgeKeyFile != "" {
+		var err error
+		if dataKey, err = p.decryptDataKey(metadata); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var encrypted []string
+	resolved, err := resolveEncrypted(document, "", nil, dataKey, &encrypted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, _ := resolved.(map[string]any)
+	return result, encrypted, nil
+}
+
+// resolveEncrypted 는 트리를 순회하며 암호화된 값을 복호화하거나 마스킹합니다.
+// sopsPath 는 SOPS 가 추가 인증 데이터로 사용하는 경로로, 배열 인덱스는 포함하지 않습니다.
+func resolveEncrypted(value any, key string, sopsPath []string, dataKey []byte, encrypted *[]string) (any, error) {
+	switch value := value.(type) {
+	case map[string]any:
+		for childKey, childVal := range value {
+			resolved, err := resolveEncrypted(childVal, domain.MapKey(key, childKey), append(sopsPath[:len(sopsPath):len(sopsPath)], childKey), dataKey, encrypted)
+			if err != nil {
+				return nil, err
+			}
+			value[childKey] = resolved
+		}
+		return value, nil
+	case []any:
+		for idx, elem := range value {
+			resolved, err := resolveEncrypted(elem, domain.SliceKey(key, idx), sopsPath, dataKey, encrypted)
+			if err != nil {
+				return nil, err
+			}
+			value[idx] = resolved
+		}
+		return value, nil
+	case string:
+		enc, ok := parseEncryptedValue(value)
+		if !ok {
+			return value, nil
+		}
+		*encrypted = append(*encrypted, key)
+
+		if dataKey == nil {
+			return domain.MaskedValue{Type: sopsEntryType(enc.valueType), Display: encryptedMarker}, nil
+		}
+
+		return enc.decrypt(dataKey, strings.Join(sopsPath, ":")+":")
+	default:
+		return value, nil
+	}
+}
+
+func parseEncryptedValue(value string) (encryptedValue, bool) {
+	matches := encryptedValuePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return encryptedValue{}, false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(matches[1])
+	if err != nil {
+		return encryptedValue{}, false
+	}
+	iv, err := base64.StdEncoding.DecodeString(matches[2])
+	if err != nil {
+		return encryptedValue{}, false
+	}
+	tag, err := base64.StdEncoding.DecodeString(matches[3])
+	if err != nil {
+		return encryptedValue{}, false
+	}
+
+	return encryptedValue{data: data, iv: iv, tag: tag, valueType: matches[4]}, true
+}
+
+func (e encryptedValue) decrypt(dataKey []byte, additionalData string) (any, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(e.iv))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, e.iv, append(e.data, e.tag...), []byte(additionalData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt sops value: %w", err)
+	}
+
+	switch e.valueType {
+	case "int":
+		return strconv.Atoi(string(plaintext))
+	case "float":
+		return strconv.ParseFloat(string(plaintext), 64)
+	case "bool":
+		return strconv.ParseBool(string(plaintext))
+	default:
+		return string(plaintext), nil
+	}
+}
+
+// decryptDataKey 는 age 키 파일의 identity 로 SOPS 데이터 키를 복호화합니다.
+func (p parser) decryptDataKey(metadata map[string]any) ([]byte, error) {
+	keyFile, err := os.Open(p.config.AgeKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	defer keyFile.Close()
+
+	identities, err := age.ParseIdentities(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, _ := metadata["age"].([]any)
+	for _, recipient := range recipients {
+		recipientMap, _ := recipient.(map[string]any)
+		enc, ok := recipientMap["enc"].(string)
+		if !ok {
+			continue
+		}
+
+		reader, err := age.Decrypt(armor.NewReader(strings.NewReader(enc)), identities...)
+		if err != nil {
+			continue
+		}
+
+		dataKey := make([]byte, 32)
+		if _, err = io.ReadFull(reader, dataKey); err != nil {
+			return nil, err
+		}
+
+		return dataKey, nil
+	}
+
+	return nil, errors.New("no age identity matched the sops recipients")
+}
+
+func sopsEntryType(valueType string) string {
+	switch valueType {
+	case "str", "bytes":
+		return "string"
+	default:
+		return valueType
+	}
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/stretchr/testify/assert"
+)
+
+func encryptSOPSValue(t *testing.T, dataKey []byte, value string, valueType string, additionalData string) string {
+	block, err := aes.NewCipher(dataKey)
+	assert.NoError(t, err)
+	gcm, err := cipher.NewGCMWithNonceSize(block, 32)
+	assert.NoError(t, err)
+
+	iv := make([]byte, 32)
+	_, err = rand.Read(iv)
+	assert.NoError(t, err)
+
+	sealed := gcm.Seal(nil, iv, []byte(value), []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		valueType,
+	)
+}
+
+func writeSOPSFile(t *testing.T, identity *age.X25519Identity) string {
+	dataKey := make([]byte, 32)
+	_, err := rand.Read(dataKey)
+	assert.NoError(t, err)
+
+	var armored bytes.Buffer
+	armorWriter := armor.NewWriter(&armored)
+	ageWriter, err := age.Encrypt(armorWriter, identity.Recipient())
+	assert.NoError(t, err)
+	_, err = ageWriter.Write(dataKey)
+	assert.NoError(t, err)
+	assert.NoError(t, ageWriter.Close())
+	assert.NoError(t, armorWriter.Close())
+
+	content := fmt.Sprintf(`database:
+  password: %s
+  port: %s
+  hosts:
+    - %s
+name_unencrypted: app
+sops:
+  age:
+    - recipient: %s
+      enc: |
+%s
+  mac: %s
+`,
+		encryptSOPSValue(t, dataKey, "hunter2", "str", "database:password:"),
+		encryptSOPSValue(t, dataKey, "5432", "int", "database:port:"),
+		encryptSOPSValue(t, dataKey, "db-0", "str", "database:hosts:"),
+		identity.Recipient(),
+		indent(armored.String(), "        "),
+		encryptSOPSValue(t, dataKey, "mac", "str", ""),
+	)
+
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func indent(text string, prefix string) string {
+	var buf bytes.Buffer
+	for _, line := range bytes.Split(bytes.TrimSpace([]byte(text)), []byte("\n")) {
+		buf.WriteString(prefix)
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+func Test_parser_resolveSOPS(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	assert.NoError(t, err)
+
+	secretsPath := writeSOPSFile(t, identity)
+	keyPath := filepath.Join(t.TempDir(), "keys.txt")
+	assert.NoError(t, os.WriteFile(keyPath, []byte(identity.String()+"\n"), 0600))
+
+	tests := []struct {
+		name          string
+		config        Config
+		wantValues    map[string]any
+		wantEncrypted []string
+	}{
+		{
+			name:   "키 파일이 없으면 암호화된 값을 같은 값으로 마스킹",
+			config: Config{},
+			wantValues: map[string]any{
+				"database": map[string]any{
+					"password": domain.MaskedValue{Type: "string", Display: encryptedMarker},
+					"port":     domain.MaskedValue{Type: "int", Display: encryptedMarker},
+					"hosts":    []any{domain.MaskedValue{Type: "string", Display: encryptedMarker}},
+				},
+				"name_unencrypted": "app",
+			},
+			wantEncrypted: []string{"database.hosts[0]", "database.password", "database.port"},
+		},
+		{
+			name:   "age 키 파일로 복호화",
+			config: Config{AgeKeyFile: keyPath},
+			wantValues: map[string]any{
+				"database": map[string]any{
+					"password": "hunter2",
+					"port":     5432,
+					"hosts":    []any{"db-0"},
+				},
+				"name_unencrypted": "app",
+			},
+			wantEncrypted: []string{"database.hosts[0]", "database.password", "database.port"},
+		},
+		{
+			name:   "여러 문서로 읽는 경우 문서별로 복호화",
+			config: Config{AgeKeyFile: keyPath, DocumentKey: []string{"name_unencrypted"}},
+			wantValues: map[string]any{
+				"app": map[string]any{
+					"database": map[string]any{
+						"password": "hunter2",
+						"port":     5432,
+						"hosts":    []any{"db-0"},
+					},
+					"name_unencrypted": "app",
+				},
+			},
+			wantEncrypted: []string{"app.database.hosts[0]", "app.database.password", "app.database.port"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser{config: tt.config}
+			got, err := p.parseFile(secretsPath)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValues, got.values)
+			assert.ElementsMatch(t, tt.wantEncrypted, got.encrypted)
+		})
+	}
+}
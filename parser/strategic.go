@@ -0,0 +1,227 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+const (
+	patchDirective = "$patch"
+	patchDelete    = "delete"
+	patchReplace   = "replace"
+)
+
+// DefaultMergeKeys 는 Kubernetes 리소스에서 자주 쓰이는 배열 필드의 병합 키입니다.
+// 키는 배열 경로 패턴이며, 같은 필드 이름이라도 위치에 따라 병합 키가 다른 경우(ex. ports)를 구분합니다.
+var DefaultMergeKeys = map[string]string{
+	"**.containers":                   "name",
+	"**.initContainers":               "name",
+	"**.ephemeralContainers":          "name",
+	"**.volumes":                      "name",
+	"**.volumeMounts":                 "mountPath",
+	"**.env":                          "name",
+	"**.envFrom":                      "prefix",
+	"**.containers[*].ports":          "containerPort",
+	"**.initContainers[*].ports":      "containerPort",
+	"**.ephemeralContainers[*].ports": "containerPort",
+	"**.spec.ports":                   "port",
+	"**.imagePullSecrets":             "name",
+	"**.tolerations":                  "key",
+	"**.hostAliases":                  "ip",
+}
+
+// NewMergeKeys 는 "field=key" 형식의 값을 DefaultMergeKeys 위에 덮어쓴 병합 키 맵을 생성합니다.
+// field 는 배열 경로 패턴(ex. "**.spec.ports")이며, 필드 이름만 주어지면 모든 위치의 같은 이름 배열에 적용됩니다.
+func NewMergeKeys(values []string) (map[string]string, error) {
+	result := make(map[string]string, len(DefaultMergeKeys)+len(values))
+	for field, key := range DefaultMergeKeys {
+		result[field] = key
+	}
+
+	for _, value := range values {
+		field, key, ok := strings.Cut(value, "=")
+		if !ok || field == "" || key == "" {
+			return nil, fmt.Errorf("invalid merge key: %s (expected field=key)", value)
+		}
+		result[mergeKeyPattern(field)] = key
+	}
+
+	return result, nil
+}
+
+func mergeKeyPattern(field string) string {
+	if strings.ContainsAny(field, ".*[") {
+		return field
+	}
+
+	return domain.MapKey("**", field)
+}
+
+// mergeKey 는 path 의 배열에 적용할 병합 키를 찾습니다. 여러 패턴이 일치하면 가장 구체적인 패턴을 사용합니다.
+func (p parser) mergeKey(path string) (string, bool) {
+	segments := domain.SplitPath(path)
+	result, found, specificity := "", false, -1
+	for field, key := range p.config.MergeKeys {
+		pattern := domain.NewPathPattern(mergeKeyPattern(field))
+		if !pattern.MatchSegments(segments) {
+			continue
+		}
+
+		score := pattern.Specificity()
+		if score > specificity || (score == specificity && key < result) {
+			result, found, specificity = key, true, score
+		}
+	}
+
+	return result, found
+}
+
+// strategicMerge 는 Kustomize 의 strategic merge patch 규칙에 따라 patch 를 dst 에 적용합니다.
+// 맵은 재귀적으로 병합되고, 병합 키가 지정된 배열은 키 값이 같은 요소끼리 병합되며,
+// null 값과 `$patch: delete` 는 해당 키 또는 배열 요소를 삭제합니다.
+func (p parser) strategicMerge(dst map[string]any, patch map[string]any, parent string, source string, origins domain.Origins) map[string]any {
+	if patch[patchDirective] == patchReplace {
+		clearOrigins(origins, parent)
+		replaced, _ := stripDirectives(patch).(map[string]any)
+		recordOrigins(replaced, parent, source, origins)
+		return replaced
+	}
+
+	if dst == nil {
+		dst = make(map[string]any)
+	}
+
+	for key, patchVal := range patch {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		nextKey := domain.MapKey(parent, key)
+
+		if patchVal == nil || isDeleteDirective(patchVal) {
+			delete(dst, key)
+			clearOrigins(origins, nextKey)
+			continue
+		}
+
+		switch patchVal := patchVal.(type) {
+		case map[string]any:
+			dstMap, _ := dst[key].(map[string]any)
+			if dstMap == nil {
+				clearOrigins(origins, nextKey)
+			}
+			dst[key] = p.strategicMerge(dstMap, patchVal, nextKey, source, origins)
+		case []any:
+			dstList, isList := dst[key].([]any)
+			mergeKey, hasMergeKey := p.mergeKey(nextKey)
+			clearOrigins(origins, nextKey)
+			if isList && hasMergeKey {
+				dst[key] = p.mergeList(dstList, patchVal, mergeKey, nextKey, source, origins)
+			} else {
+				dst[key] = stripDirectives(patchVal)
+			}
+		default:
+			clearOrigins(origins, nextKey)
+			dst[key] = patchVal
+		}
+		setOrigin(origins, nextKey, source)
+	}
+
+	return dst
+}
+
+// mergeList 는 mergeKey 값이 같은 요소끼리 병합하고, 새 요소는 뒤에 추가합니다.
+func (p parser) mergeList(dst []any, patch []any, mergeKey string, parent string, source string, origins domain.Origins) []any {
+	result := make([]any, len(dst))
+	copy(result, dst)
+
+	for _, patchElem := range patch {
+		patchMap, ok := patchElem.(map[string]any)
+		if !ok {
+			result = append(result, patchElem)
+			continue
+		}
+
+		if patchMap[patchDirective] == patchReplace {
+			return stripDirectives(removeElement(patch, patchElem)).([]any)
+		}
+
+		idx := findByMergeKey(result, mergeKey, patchMap[mergeKey])
+		if patchMap[patchDirective] == patchDelete {
+			if idx >= 0 {
+				result = append(result[:idx], result[idx+1:]...)
+			}
+			continue
+		}
+
+		if idx < 0 {
+			result = append(result, stripDirectives(patchMap))
+			continue
+		}
+
+		dstMap, _ := result[idx].(map[string]any)
+		result[idx] = p.strategicMerge(dstMap, patchMap, domain.SliceKey(parent, idx), source, origins)
+	}
+
+	return result
+}
+
+func findByMergeKey(list []any, mergeKey string, value any) int {
+	if value == nil {
+		return -1
+	}
+
+	for idx, elem := range list {
+		elemMap, ok := elem.(map[string]any)
+		if ok && reflect.DeepEqual(elemMap[mergeKey], value) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func removeElement(list []any, target any) []any {
+	result := make([]any, 0, len(list))
+	for _, elem := range list {
+		if reflect.DeepEqual(elem, target) {
+			continue
+		}
+		result = append(result, elem)
+	}
+
+	return result
+}
+
+func isDeleteDirective(value any) bool {
+	valueMap, ok := value.(map[string]any)
+	return ok && valueMap[patchDirective] == patchDelete
+}
+
+// stripDirectives 는 병합 대상이 없는 값에서 `$patch` 와 같은 지시자를 제거합니다.
+func stripDirectives(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for key, val := range value {
+			if strings.HasPrefix(key, "$") {
+				continue
+			}
+			result[key] = stripDirectives(val)
+		}
+		return result
+	case []any:
+		result := make([]any, 0, len(value))
+		for _, elem := range value {
+			if isDeleteDirective(elem) {
+				continue
+			}
+			result = append(result, stripDirectives(elem))
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parser_strategicMerge(t *testing.T) {
+	type args struct {
+		dst   map[string]any
+		patch map[string]any
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]any
+	}{
+		{
+			name: "병합 키로 배열 요소 병합",
+			args: args{
+				dst: map[string]any{"containers": []any{
+					map[string]any{"name": "app", "image": "app:1"},
+					map[string]any{"name": "sidecar", "image": "proxy:1"},
+				}},
+				patch: map[string]any{"containers": []any{
+					map[string]any{"name": "app", "image": "app:2"},
+					map[string]any{"name": "metrics", "image": "metrics:1"},
+				}},
+			},
+			want: map[string]any{"containers": []any{
+				map[string]any{"name": "app", "image": "app:2"},
+				map[string]any{"name": "sidecar", "image": "proxy:1"},
+				map[string]any{"name": "metrics", "image": "metrics:1"},
+			}},
+		},
+		{
+			name: "$patch: delete 로 배열 요소 삭제",
+			args: args{
+				dst: map[string]any{"containers": []any{
+					map[string]any{"name": "app", "image": "app:1"},
+					map[string]any{"name": "sidecar", "image": "proxy:1"},
+				}},
+				patch: map[string]any{"containers": []any{
+					map[string]any{"name": "sidecar", "$patch": "delete"},
+				}},
+			},
+			want: map[string]any{"containers": []any{
+				map[string]any{"name": "app", "image": "app:1"},
+			}},
+		},
+		{
+			name: "$patch: delete 로 맵 삭제",
+			args: args{
+				dst:   map[string]any{"spec": map[string]any{"affinity": map[string]any{"a": 1}, "replicas": 1}},
+				patch: map[string]any{"spec": map[string]any{"affinity": map[string]any{"$patch": "delete"}}},
+			},
+			want: map[string]any{"spec": map[string]any{"replicas": 1}},
+		},
+		{
+			name: "$patch: replace 로 맵 교체",
+			args: args{
+				dst:   map[string]any{"labels": map[string]any{"a": "1", "b": "2"}},
+				patch: map[string]any{"labels": map[string]any{"$patch": "replace", "c": "3"}},
+			},
+			want: map[string]any{"labels": map[string]any{"c": "3"}},
+		},
+		{
+			name: "병합 키가 없는 배열은 교체",
+			args: args{
+				dst:   map[string]any{"args": []any{"--a", "--b"}},
+				patch: map[string]any{"args": []any{"--c"}},
+			},
+			want: map[string]any{"args": []any{"--c"}},
+		},
+		{
+			name: "Service 의 ports 는 port 로 병합",
+			args: args{
+				dst: map[string]any{"spec": map[string]any{"ports": []any{
+					map[string]any{"name": "http", "port": 80, "targetPort": 8080},
+				}}},
+				patch: map[string]any{"spec": map[string]any{"ports": []any{
+					map[string]any{"port": 80, "targetPort": 9090},
+					map[string]any{"name": "https", "port": 443},
+				}}},
+			},
+			want: map[string]any{"spec": map[string]any{"ports": []any{
+				map[string]any{"name": "http", "port": 80, "targetPort": 9090},
+				map[string]any{"name": "https", "port": 443},
+			}}},
+		},
+		{
+			name: "컨테이너의 ports 는 containerPort 로 병합",
+			args: args{
+				dst: map[string]any{"containers": []any{
+					map[string]any{"name": "app", "ports": []any{
+						map[string]any{"containerPort": 8080, "protocol": "TCP"},
+					}},
+				}},
+				patch: map[string]any{"containers": []any{
+					map[string]any{"name": "app", "ports": []any{
+						map[string]any{"containerPort": 8080, "name": "http"},
+					}},
+				}},
+			},
+			want: map[string]any{"containers": []any{
+				map[string]any{"name": "app", "ports": []any{
+					map[string]any{"containerPort": 8080, "protocol": "TCP", "name": "http"},
+				}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser{config: Config{MergeKeys: DefaultMergeKeys}}
+			got := p.strategicMerge(tt.args.dst, tt.args.patch, "", "patch.yaml", nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parser_strategicMerge_Origins(t *testing.T) {
+	type args struct {
+		dst   map[string]any
+		patch map[string]any
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantOrigins domain.Origins
+	}{
+		{
+			name: "패치한 경로만 패치 파일을 출처로 기록",
+			args: args{
+				dst:   map[string]any{"spec": map[string]any{"replicas": 1, "paused": false}},
+				patch: map[string]any{"spec": map[string]any{"replicas": 3}},
+			},
+			wantOrigins: domain.Origins{"spec": "patch.yaml", "spec.replicas": "patch.yaml", "spec.paused": "base.yaml"},
+		},
+		{
+			name: "병합 키로 병합한 배열 요소의 출처 기록",
+			args: args{
+				dst: map[string]any{"containers": []any{
+					map[string]any{"name": "app", "image": "app:1"},
+				}},
+				patch: map[string]any{"containers": []any{
+					map[string]any{"name": "app", "image": "app:2"},
+				}},
+			},
+			wantOrigins: domain.Origins{
+				"containers":          "patch.yaml",
+				"containers[0].name":  "patch.yaml",
+				"containers[0].image": "patch.yaml",
+			},
+		},
+		{
+			name: "$patch: delete 로 삭제한 경로의 출처 제거",
+			args: args{
+				dst:   map[string]any{"spec": map[string]any{"affinity": map[string]any{"a": 1}, "replicas": 1}},
+				patch: map[string]any{"spec": map[string]any{"affinity": map[string]any{"$patch": "delete"}}},
+			},
+			wantOrigins: domain.Origins{"spec": "patch.yaml", "spec.replicas": "base.yaml"},
+		},
+		{
+			name: "문서 루트의 $patch: replace 는 기존 출처를 모두 제거",
+			args: args{
+				dst:   map[string]any{"a": 1, "b": map[string]any{"c": 2}},
+				patch: map[string]any{"$patch": "replace", "d": 3},
+			},
+			wantOrigins: domain.Origins{"d": "patch.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origins := make(domain.Origins)
+			recordOrigins(tt.args.dst, "", "base.yaml", origins)
+
+			p := parser{config: Config{MergeKeys: DefaultMergeKeys}}
+			p.strategicMerge(tt.args.dst, tt.args.patch, "", "patch.yaml", origins)
+			assert.Equal(t, tt.wantOrigins, origins)
+		})
+	}
+}
+
+func TestNewMergeKeys(t *testing.T) {
+	keys, err := NewMergeKeys([]string{"sidecars=name", "**.spec.ports=name"})
+	assert.NoError(t, err)
+
+	p := parser{config: Config{MergeKeys: keys}}
+	for path, want := range map[string]string{
+		"spec.sidecars":                          "name",
+		"spec.ports":                             "name",
+		"spec.template.spec.containers[0].ports": "containerPort",
+		"spec.template.spec.containers":          "name",
+	} {
+		got, ok := p.mergeKey(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, want, got, path)
+	}
+
+	_, ok := p.mergeKey("spec.args")
+	assert.False(t, ok)
+
+	_, err = NewMergeKeys([]string{"containers"})
+	assert.Error(t, err)
+}
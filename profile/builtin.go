@@ -0,0 +1,140 @@
+package profile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+const (
+	Kubernetes    = "kubernetes"
+	Compose       = "compose"
+	GitHubActions = "github-actions"
+	OpenAPI       = "openapi"
+
+	// None 은 자동 감지를 끄고 프로파일 없이 비교합니다.
+	None = "none"
+)
+
+// builtins 는 내장 프로파일입니다.
+var builtins = map[string]domain.Profile{
+	Kubernetes: {
+		Name:        Kubernetes,
+		DocumentKey: []string{"apiVersion", "kind", "metadata.namespace", "metadata.name"},
+		Ignore: []string{
+			"status",
+			"metadata.managedFields",
+			"metadata.resourceVersion",
+			"metadata.uid",
+			"metadata.generation",
+			"metadata.selfLink",
+			"metadata.annotations.kubectl.kubernetes.io/last-applied-configuration",
+			"metadata.annotations.deployment.kubernetes.io/revision",
+			"**.metadata.creationTimestamp",
+		},
+		ArrayKeys: []domain.ArrayKey{
+			{Path: "**.containers", Key: "name"},
+			{Path: "**.initContainers", Key: "name"},
+			{Path: "**.ephemeralContainers", Key: "name"},
+			{Path: "**.containers[*].ports", Key: "containerPort"},
+			{Path: "**.containers[*].env", Key: "name"},
+			{Path: "**.containers[*].volumeMounts", Key: "mountPath"},
+			{Path: "**.volumes", Key: "name"},
+		},
+	},
+	Compose: {
+		Name:   Compose,
+		Ignore: []string{"version"},
+		Normalizers: []domain.Normalizer{
+			{Path: "services.*.environment", Type: KeyValueNormalizer},
+			{Path: "services.*.environment.*", Type: StringNormalizer},
+			{Path: "services.*.labels", Type: KeyValueNormalizer},
+			{Path: "services.*.labels.*", Type: StringNormalizer},
+			{Path: "services.*.ports", Type: SortNormalizer},
+			{Path: "services.*.ports[*]", Type: StringNormalizer},
+			{Path: "services.*.depends_on", Type: SortNormalizer},
+			{Path: "services.*.command", Type: ListNormalizer},
+			{Path: "services.*.entrypoint", Type: ListNormalizer},
+		},
+	},
+	GitHubActions: {
+		Name: GitHubActions,
+		Normalizers: []domain.Normalizer{
+			{Path: "on", Type: ListNormalizer},
+			{Path: "on", Type: KeyValueNormalizer},
+			{Path: "jobs.*.needs", Type: ListNormalizer},
+			{Path: "jobs.*.needs", Type: SortNormalizer},
+			{Path: "jobs.*.runs-on", Type: ListNormalizer},
+		},
+	},
+	OpenAPI: {
+		Name: OpenAPI,
+		ArrayKeys: []domain.ArrayKey{
+			{Path: "**.parameters", Key: "name"},
+			{Path: "servers", Key: "url"},
+			{Path: "tags", Key: "name"},
+		},
+		Normalizers: []domain.Normalizer{
+			{Path: "**.required", Type: SortNormalizer},
+			{Path: "**.enum", Type: SortNormalizer},
+		},
+	},
+}
+
+// detectOrder 는 자동 감지 시 프로파일을 확인하는 순서입니다.
+var detectOrder = []string{Kubernetes, OpenAPI, GitHubActions, Compose}
+
+var detectors = map[string]func(document map[string]any) bool{
+	Kubernetes: func(document map[string]any) bool {
+		apiVersion, _ := document["apiVersion"].(string)
+		kind, _ := document["kind"].(string)
+		return apiVersion != "" && kind != ""
+	},
+	OpenAPI: func(document map[string]any) bool {
+		version, _ := document["openapi"].(string)
+		_, hasPaths := document["paths"]
+		return strings.HasPrefix(version, "3.") && hasPaths
+	},
+	GitHubActions: func(document map[string]any) bool {
+		_, hasOn := document["on"]
+		_, hasJobs := document["jobs"].(map[string]any)
+		return hasOn && hasJobs
+	},
+	Compose: func(document map[string]any) bool {
+		if _, ok := document["services"].(map[string]any); !ok {
+			return false
+		}
+
+		for key := range document {
+			switch key {
+			case "version", "name", "services", "networks", "volumes", "configs", "secrets", "include":
+			default:
+				if !strings.HasPrefix(key, "x-") {
+					return false
+				}
+			}
+		}
+		return true
+	},
+}
+
+func Builtin(name string) (domain.Profile, error) {
+	profile, ok := builtins[name]
+	if !ok {
+		return domain.Profile{}, fmt.Errorf("unsupported profile: %s", name)
+	}
+
+	return profile, nil
+}
+
+// Detect 는 문서의 형태로 내장 프로파일을 찾습니다.
+func Detect(document map[string]any) (domain.Profile, bool) {
+	for _, name := range detectOrder {
+		if detectors[name](document) {
+			return builtins[name], true
+		}
+	}
+
+	return domain.Profile{}, false
+}
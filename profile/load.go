@@ -0,0 +1,108 @@
+package profile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resolve 는 --profile 값으로 프로파일을 결정합니다.
+// 값이 비어 있으면 documentPath 의 첫 문서 형태로 내장 프로파일을 자동 감지하고,
+// 내장 프로파일 이름이 아닌 경우 사용자 프로파일 파일 경로로 취급합니다.
+func Resolve(name string, documentPath string) (domain.Profile, error) {
+	switch {
+	case name == None:
+		return domain.Profile{}, nil
+	case name == "":
+		document, err := firstDocument(documentPath)
+		if err != nil {
+			return domain.Profile{}, err
+		}
+
+		detected, _ := Detect(document)
+		return detected, nil
+	}
+
+	if profile, ok := builtins[name]; ok {
+		return profile, nil
+	}
+
+	return Load(name)
+}
+
+// Load 는 사용자 정의 프로파일 파일을 읽습니다. extends 로 내장 프로파일의 설정을 이어받을 수 있습니다.
+func Load(path string) (domain.Profile, error) {
+	var profile domain.Profile
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+
+	if err = yaml.Unmarshal(content, &profile); err != nil {
+		return domain.Profile{}, err
+	}
+
+	for idx, arrayKey := range profile.ArrayKeys {
+		if arrayKey.Path == "" || arrayKey.Key == "" {
+			return domain.Profile{}, fmt.Errorf("arrayKeys %d: path and key are required", idx)
+		}
+	}
+
+	for idx, normalizer := range profile.Normalizers {
+		if normalizer.Path == "" {
+			return domain.Profile{}, fmt.Errorf("normalizers %d: path is required", idx)
+		}
+		if _, err = NewNormalizerType(string(normalizer.Type)); err != nil {
+			return domain.Profile{}, fmt.Errorf("normalizers %d: %w", idx, err)
+		}
+	}
+
+	if profile.Extends == "" {
+		return profile, nil
+	}
+
+	base, err := Builtin(profile.Extends)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+
+	return extend(base, profile), nil
+}
+
+// extend 는 base 설정 뒤에 profile 의 설정을 덧붙입니다. DocumentKey 는 profile 에 지정된 경우 대체됩니다.
+func extend(base domain.Profile, profile domain.Profile) domain.Profile {
+	result := domain.Profile{
+		Name:        profile.Name,
+		Extends:     profile.Extends,
+		DocumentKey: base.DocumentKey,
+		Ignore:      append(append([]string(nil), base.Ignore...), profile.Ignore...),
+		ArrayKeys:   append(append([]domain.ArrayKey(nil), base.ArrayKeys...), profile.ArrayKeys...),
+		Normalizers: append(append([]domain.Normalizer(nil), base.Normalizers...), profile.Normalizers...),
+	}
+	if len(profile.DocumentKey) > 0 {
+		result.DocumentKey = profile.DocumentKey
+	}
+
+	return result
+}
+
+func firstDocument(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]any
+	if err = yaml.NewDecoder(bytes.NewReader(content)).Decode(&document); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return document, nil
+}
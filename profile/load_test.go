@@ -0,0 +1,79 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Detect(t *testing.T) {
+	tests := []struct {
+		name     string
+		document map[string]any
+		want     string
+		found    bool
+	}{
+		{name: "kubernetes", document: map[string]any{"apiVersion": "v1", "kind": "Service"}, want: Kubernetes, found: true},
+		{name: "openapi", document: map[string]any{"openapi": "3.1.0", "paths": map[string]any{}}, want: OpenAPI, found: true},
+		{name: "github actions", document: map[string]any{"on": "push", "jobs": map[string]any{}}, want: GitHubActions, found: true},
+		{name: "compose", document: map[string]any{"services": map[string]any{}, "x-common": 1}, want: Compose, found: true},
+		{name: "services in application config", document: map[string]any{"services": map[string]any{}, "server": 1}, found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := Detect(tt.document)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got.Name)
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profile.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`name: helm-values
+extends: openapi
+ignore:
+  - info.version
+arrayKeys:
+  - path: "x-environments"
+    key: name
+normalizers:
+  - path: "**.tags"
+    type: sort
+`), 0644))
+
+	got, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "helm-values", got.Name)
+	assert.Equal(t, []string{"info.version"}, got.Ignore)
+	assert.Equal(t, domain.ArrayKey{Path: "x-environments", Key: "name"}, got.ArrayKeys[len(got.ArrayKeys)-1])
+	assert.Len(t, got.ArrayKeys, len(builtins[OpenAPI].ArrayKeys)+1)
+	assert.Len(t, got.Normalizers, len(builtins[OpenAPI].Normalizers)+1)
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalidPath, []byte("normalizers:\n  - path: a\n    type: upper\n"), 0644))
+	_, err = Load(invalidPath)
+	assert.Error(t, err)
+}
+
+func Test_Resolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("services:\n  web:\n    image: nginx\n"), 0644))
+
+	detected, err := Resolve("", path)
+	assert.NoError(t, err)
+	assert.Equal(t, Compose, detected.Name)
+
+	none, err := Resolve(None, path)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Profile{}, none)
+
+	kubernetes, err := Resolve(Kubernetes, path)
+	assert.NoError(t, err)
+	assert.Equal(t, Kubernetes, kubernetes.Name)
+}
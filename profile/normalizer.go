@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+const (
+	// ListNormalizer 는 단일 값을 원소가 하나인 배열로 바꿉니다. ex. needs: build → needs: [build]
+	ListNormalizer domain.NormalizerType = "list"
+	// SortNormalizer 는 순서가 의미 없는 배열을 문자열 표현 순으로 정렬합니다.
+	SortNormalizer domain.NormalizerType = "sort"
+	// StringNormalizer 는 스칼라 값을 문자열로 바꿉니다. ex. 8080 → "8080"
+	StringNormalizer domain.NormalizerType = "string"
+	// KeyValueNormalizer 는 "KEY=VALUE" 문자열 배열을 맵으로 바꿉니다. "=" 이 없는 원소의 값은 null 입니다.
+	KeyValueNormalizer domain.NormalizerType = "keyValue"
+)
+
+func NewNormalizerType(normalizerType string) (domain.NormalizerType, error) {
+	switch domain.NormalizerType(normalizerType) {
+	case ListNormalizer, SortNormalizer, StringNormalizer, KeyValueNormalizer:
+		return domain.NormalizerType(normalizerType), nil
+	default:
+		return "", fmt.Errorf("unsupported normalizer type: %s", normalizerType)
+	}
+}
+
+func normalize(normalizerType domain.NormalizerType, value any) any {
+	switch normalizerType {
+	case ListNormalizer:
+		switch value.(type) {
+		case []any, map[string]any, nil:
+			return value
+		default:
+			return []any{value}
+		}
+	case SortNormalizer:
+		list, ok := value.([]any)
+		if !ok {
+			return value
+		}
+
+		sorted := append([]any(nil), list...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return fmt.Sprint(sorted[i]) < fmt.Sprint(sorted[j])
+		})
+		return sorted
+	case StringNormalizer:
+		switch value.(type) {
+		case []any, map[string]any, nil, string, domain.MaskedValue:
+			return value
+		default:
+			return fmt.Sprint(value)
+		}
+	case KeyValueNormalizer:
+		list, ok := value.([]any)
+		if !ok {
+			return value
+		}
+
+		result := make(map[string]any, len(list))
+		for _, elem := range list {
+			key, val, found := strings.Cut(fmt.Sprint(elem), "=")
+			if found {
+				result[key] = val
+			} else {
+				result[key] = nil
+			}
+		}
+		return result
+	default:
+		return value
+	}
+}
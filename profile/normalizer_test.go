@@ -0,0 +1,76 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applier_Normalize_compose(t *testing.T) {
+	compose, err := Builtin(Compose)
+	assert.NoError(t, err)
+	a := New(Config{Profile: compose})
+
+	lhs := map[string]any{
+		"version": "3.8",
+		"services": map[string]any{
+			"web": map[string]any{
+				"environment": []any{"PORT=8080", "DEBUG"},
+				"ports":       []any{"443:443", 80},
+				"command":     "serve",
+			},
+		},
+	}
+	rhs := map[string]any{
+		"services": map[string]any{
+			"web": map[string]any{
+				"environment": map[string]any{"PORT": 8080, "DEBUG": nil},
+				"ports":       []any{"80", "443:443"},
+				"command":     []any{"serve"},
+			},
+		},
+	}
+
+	assert.Equal(t, a.Normalize(rhs), a.Normalize(lhs))
+}
+
+func Test_applier_Normalize_githubActions(t *testing.T) {
+	actions, err := Builtin(GitHubActions)
+	assert.NoError(t, err)
+	a := New(Config{Profile: actions})
+
+	lhs := map[string]any{
+		"on":   []any{"push", "pull_request"},
+		"jobs": map[string]any{"deploy": map[string]any{"needs": []any{"test", "build"}, "runs-on": "ubuntu-latest"}},
+	}
+	rhs := map[string]any{
+		"on":   map[string]any{"push": nil, "pull_request": nil},
+		"jobs": map[string]any{"deploy": map[string]any{"needs": []any{"build", "test"}, "runs-on": []any{"ubuntu-latest"}}},
+	}
+
+	assert.Equal(t, a.Normalize(rhs), a.Normalize(lhs))
+}
+
+func Test_normalize(t *testing.T) {
+	tests := []struct {
+		name           string
+		normalizerType domain.NormalizerType
+		value          any
+		want           any
+	}{
+		{name: "list wraps scalar", normalizerType: ListNormalizer, value: "build", want: []any{"build"}},
+		{name: "list keeps list", normalizerType: ListNormalizer, value: []any{"build"}, want: []any{"build"}},
+		{name: "sort", normalizerType: SortNormalizer, value: []any{"b", 1, "a"}, want: []any{1, "a", "b"}},
+		{name: "string", normalizerType: StringNormalizer, value: 8080, want: "8080"},
+		{name: "string keeps null", normalizerType: StringNormalizer, value: nil, want: nil},
+		{name: "keyValue", normalizerType: KeyValueNormalizer, value: []any{"A=1", "B"}, want: map[string]any{"A": "1", "B": nil}},
+		{name: "keyValue keeps map", normalizerType: KeyValueNormalizer, value: map[string]any{"A": "1"}, want: map[string]any{"A": "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalize(tt.normalizerType, tt.value))
+		})
+	}
+}
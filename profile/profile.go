@@ -0,0 +1,184 @@
+package profile
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+type Applier interface {
+	Normalize(document map[string]any) map[string]any
+	Rules() domain.Rules
+	Group(results domain.ErrorResults, lhs map[string]any, rhs map[string]any)
+}
+
+type Config struct {
+	Profile domain.Profile
+}
+
+type applier struct {
+	config      Config
+	ignore      []domain.PathPattern
+	normalizers []domain.PathPattern
+}
+
+func New(config Config) Applier {
+	ignore := make([]domain.PathPattern, 0, len(config.Profile.Ignore))
+	for _, pattern := range config.Profile.Ignore {
+		ignore = append(ignore, domain.NewPathPattern(pattern))
+	}
+
+	normalizers := make([]domain.PathPattern, 0, len(config.Profile.Normalizers))
+	for _, normalizer := range config.Profile.Normalizers {
+		normalizers = append(normalizers, domain.NewPathPattern(normalizer.Path))
+	}
+
+	return applier{config: config, ignore: ignore, normalizers: normalizers}
+}
+
+func (a applier) isDocumentSet() bool {
+	return len(a.config.Profile.DocumentKey) > 0
+}
+
+// Normalize 는 각 문서에서 Ignore 패턴에 일치하는 값을 제거하고 Normalizers 를 적용한 새 문서를 반환합니다.
+// 변환은 상위 경로부터 적용되므로, 맵으로 바뀐 값의 하위 경로에도 다른 변환을 지정할 수 있습니다.
+func (a applier) Normalize(document map[string]any) map[string]any {
+	if document == nil {
+		return nil
+	}
+
+	if !a.isDocumentSet() {
+		result, _ := a.strip("", document).(map[string]any)
+		return result
+	}
+
+	result := make(map[string]any, len(document))
+	for id, doc := range document {
+		result[id] = a.strip("", doc)
+	}
+
+	return result
+}
+
+func (a applier) strip(key string, value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for childKey, childVal := range value {
+			nextKey := domain.MapKey(key, childKey)
+			if a.ignored(nextKey) {
+				continue
+			}
+			stripped := a.strip(nextKey, a.applyNormalizers(nextKey, childVal))
+			if emptied(childVal, stripped) {
+				continue
+			}
+			result[childKey] = stripped
+		}
+		return result
+	case []any:
+		result := make([]any, 0, len(value))
+		for idx, elem := range value {
+			nextKey := domain.SliceKey(key, idx)
+			result = append(result, a.strip(nextKey, a.applyNormalizers(nextKey, elem)))
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// emptied 는 값이 있던 맵이 제거 후 비게 되었는지 확인합니다.
+// ex. last-applied-configuration 만 있던 annotations 는 양쪽 모두 없는 것으로 취급합니다.
+func emptied(before any, after any) bool {
+	beforeMap, ok := before.(map[string]any)
+	if !ok || len(beforeMap) == 0 {
+		return false
+	}
+
+	afterMap, _ := after.(map[string]any)
+	return len(afterMap) == 0
+}
+
+func (a applier) applyNormalizers(key string, value any) any {
+	for idx, pattern := range a.normalizers {
+		if pattern.Match(key) {
+			value = normalize(a.config.Profile.Normalizers[idx].Type, value)
+		}
+	}
+
+	return value
+}
+
+func (a applier) ignored(key string) bool {
+	for _, pattern := range a.ignore {
+		if pattern.Match(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rules 는 프로파일의 배열 식별 키를 비교 규칙으로 변환합니다.
+// 여러 문서로 구성된 경우 문서 식별자 아래의 경로와 일치하도록 "**." 를 앞에 붙입니다.
+func (a applier) Rules() domain.Rules {
+	rules := make(domain.Rules, 0, len(a.config.Profile.ArrayKeys))
+	for _, arrayKey := range a.config.Profile.ArrayKeys {
+		path := arrayKey.Path
+		if a.isDocumentSet() && !strings.HasPrefix(path, "**") {
+			path = "**." + path
+		}
+
+		rules = append(rules, domain.Rule{
+			Paths:         []string{path},
+			ArrayStrategy: comparer.KeyStrategy,
+			ArrayKey:      arrayKey.Key,
+		})
+	}
+
+	return rules
+}
+
+// Group 은 여러 문서로 구성된 경우 각 결과에 해당 문서의 식별자를 기록합니다.
+func (a applier) Group(results domain.ErrorResults, lhs map[string]any, rhs map[string]any) {
+	if !a.isDocumentSet() {
+		return
+	}
+
+	ids := documentIDs(lhs, rhs)
+	for i := range results {
+		for _, id := range ids {
+			if results[i].Key == id || strings.HasPrefix(results[i].Key, id+".") || strings.HasPrefix(results[i].Key, id+"[") {
+				results[i].Group = id
+				break
+			}
+		}
+	}
+}
+
+// documentIDs 는 양쪽 문서 식별자를 긴 것부터 정렬해 반환합니다.
+func documentIDs(lhs map[string]any, rhs map[string]any) []string {
+	set := make(map[string]bool, len(lhs)+len(rhs))
+	for id := range lhs {
+		set[id] = true
+	}
+	for id := range rhs {
+		set[id] = true
+	}
+
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) > len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
@@ -0,0 +1,72 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applier_Normalize(t *testing.T) {
+	kubernetes, err := Builtin(Kubernetes)
+	assert.NoError(t, err)
+
+	document := map[string]any{
+		"apps/v1/Deployment/default/web": map[string]any{
+			"metadata": map[string]any{
+				"name":            "web",
+				"uid":             "1234",
+				"resourceVersion": "5",
+				"annotations": map[string]any{
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				},
+			},
+			"spec": map[string]any{
+				"template": map[string]any{"metadata": map[string]any{"creationTimestamp": nil, "labels": map[string]any{"app": "web"}}},
+			},
+			"status": map[string]any{"readyReplicas": 1},
+		},
+	}
+
+	got := New(Config{Profile: kubernetes}).Normalize(document)
+	assert.Equal(t, map[string]any{
+		"apps/v1/Deployment/default/web": map[string]any{
+			"metadata": map[string]any{"name": "web"},
+			"spec": map[string]any{
+				"template": map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "web"}}},
+			},
+		},
+	}, got)
+}
+
+func Test_applier_Rules(t *testing.T) {
+	a := New(Config{Profile: domain.Profile{
+		DocumentKey: []string{"kind"},
+		ArrayKeys: []domain.ArrayKey{
+			{Path: "spec.ports", Key: "port"},
+			{Path: "**.containers", Key: "name"},
+		},
+	}})
+
+	assert.Equal(t, domain.Rules{
+		{Paths: []string{"**.spec.ports"}, ArrayStrategy: comparer.KeyStrategy, ArrayKey: "port"},
+		{Paths: []string{"**.containers"}, ArrayStrategy: comparer.KeyStrategy, ArrayKey: "name"},
+	}, a.Rules())
+}
+
+func Test_applier_Group(t *testing.T) {
+	a := New(Config{Profile: domain.Profile{DocumentKey: []string{"kind", "metadata.name"}}})
+
+	lhs := map[string]any{"Service/web": nil, "Service/web.internal": nil}
+	rhs := map[string]any{"ConfigMap/cfg": nil}
+	results := domain.ErrorResults{
+		{Key: "Service/web.spec.type"},
+		{Key: "Service/web.internal.spec.type"},
+		{Key: "ConfigMap/cfg"},
+	}
+
+	a.Group(results, lhs, rhs)
+	assert.Equal(t, []string{"Service/web", "Service/web.internal", "ConfigMap/cfg"}, []string{results[0].Group, results[1].Group, results[2].Group})
+}
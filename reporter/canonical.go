@@ -0,0 +1,168 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/samber/lo"
+
+	"gopkg.in/yaml.v3"
+)
+
+const canonicalIndent = "  "
+
+// canonicalLine 은 정규화된 YAML 의 한 줄과 그 줄이 속한 경로입니다.
+type canonicalLine struct {
+	text string
+	path string
+}
+
+// canonicalize 는 문서를 키 정렬, 2칸 들여쓰기의 YAML 로 직렬화하고 각 줄의 경로를 기록합니다.
+// 마스킹된 값은 표시용 문자열로 출력합니다.
+func canonicalize(document map[string]any) []canonicalLine {
+	var lines []canonicalLine
+	writeCanonical(&lines, "", "", "", "", document)
+
+	return lines
+}
+
+// writeCanonical 은 indent 위치에 prefix("key:", "-") 와 value 를 출력합니다.
+// lead 가 지정된 경우 첫 줄의 들여쓰기 대신 사용합니다. ex. 배열 요소인 맵의 첫 키 앞의 "- "
+func writeCanonical(lines *[]canonicalLine, indent string, lead string, prefix string, path string, value any) {
+	first := indent
+	if lead != "" {
+		first = lead
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		if len(value) == 0 {
+			*lines = append(*lines, canonicalLine{text: first + joinPrefix(prefix, "{}"), path: path})
+			return
+		}
+
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		childIndent, childLead := indent, lead
+		switch prefix {
+		case "":
+		case "-":
+			// 배열 요소인 맵은 첫 키를 "- " 와 같은 줄에 출력합니다.
+			childIndent, childLead = indent+canonicalIndent, first+"- "
+		default:
+			*lines = append(*lines, canonicalLine{text: first + prefix, path: path})
+			childIndent, childLead = indent+canonicalIndent, ""
+		}
+
+		for idx, key := range keys {
+			keyLead := ""
+			if idx == 0 {
+				keyLead = childLead
+			}
+			writeCanonical(lines, childIndent, keyLead, formatKey(key)+":", domain.MapKey(path, key), value[key])
+		}
+	case []any:
+		if len(value) == 0 {
+			*lines = append(*lines, canonicalLine{text: first + joinPrefix(prefix, "[]"), path: path})
+			return
+		}
+
+		childIndent, childLead := indent, lead
+		if prefix != "" {
+			*lines = append(*lines, canonicalLine{text: first + prefix, path: path})
+			childIndent, childLead = indent+canonicalIndent, ""
+		}
+
+		for idx, elem := range value {
+			elemLead := ""
+			if idx == 0 {
+				elemLead = childLead
+			}
+			writeCanonical(lines, childIndent, elemLead, "-", domain.SliceKey(path, idx), elem)
+		}
+	default:
+		scalarLines := strings.Split(formatScalar(value), "\n")
+		*lines = append(*lines, canonicalLine{text: first + joinPrefix(prefix, scalarLines[0]), path: path})
+		for _, line := range scalarLines[1:] {
+			*lines = append(*lines, canonicalLine{text: indent + canonicalIndent + strings.TrimPrefix(line, "    "), path: path})
+		}
+	}
+}
+
+// subtreeYAML 은 한쪽에만 존재하는 하위 트리를 정규화된 YAML 로 직렬화합니다.
+func subtreeYAML(subtree any) string {
+	var lines []canonicalLine
+	writeCanonical(&lines, "", "", "", "", subtree)
+
+	return strings.Join(lo.Map(lines, func(line canonicalLine, _ int) string { return line.text }), "\n")
+}
+
+// subtreeLabel 은 하위 트리의 타입과 값의 개수를 표시합니다. ex. (map, 3 keys)
+func subtreeLabel(entry domain.YAMLEntry) string {
+	if entry.KeyCount == 1 {
+		return fmt.Sprintf("(%s, 1 key)", entry.Type)
+	}
+
+	return fmt.Sprintf("(%s, %d keys)", entry.Type, entry.KeyCount)
+}
+
+func joinPrefix(prefix string, value string) string {
+	if prefix == "" {
+		return value
+	}
+
+	return prefix + " " + value
+}
+
+func formatKey(key string) string {
+	return formatScalar(key)
+}
+
+// formatScalar 는 스칼라 값을 YAML 표기로 변환합니다. 여러 줄 문자열은 블록 스칼라로 표기합니다.
+func formatScalar(value any) string {
+	if masked, ok := value.(domain.MaskedValue); ok {
+		value = masked.Display
+	}
+
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+// commonPath 는 경로들의 가장 가까운 공통 상위 경로를 반환합니다.
+func commonPath(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	common := domain.SplitPath(paths[0])
+	for _, path := range paths[1:] {
+		segments := domain.SplitPath(path)
+		n := 0
+		for n < len(common) && n < len(segments) && common[n] == segments[n] {
+			n++
+		}
+		common = common[:n]
+	}
+
+	path := ""
+	for _, segment := range common {
+		if strings.HasPrefix(segment, "[") {
+			path += segment
+		} else {
+			path = domain.MapKey(path, segment)
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,64 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_canonicalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		document map[string]any
+		want     []canonicalLine
+	}{
+		{
+			name:     "키 정렬",
+			document: map[string]any{"b": 1, "a": "x"},
+			want: []canonicalLine{
+				{text: "a: x", path: "a"},
+				{text: "b: 1", path: "b"},
+			},
+		},
+		{
+			name: "중첩된 맵과 배열",
+			document: map[string]any{
+				"app": map[string]any{
+					"ports": []any{80, 443},
+					"env":   []any{map[string]any{"name": "A", "value": "1"}},
+				},
+			},
+			want: []canonicalLine{
+				{text: "app:", path: "app"},
+				{text: "  env:", path: "app.env"},
+				{text: "    - name: A", path: "app.env[0].name"},
+				{text: "      value: \"1\"", path: "app.env[0].value"},
+				{text: "  ports:", path: "app.ports"},
+				{text: "    - 80", path: "app.ports[0]"},
+				{text: "    - 443", path: "app.ports[1]"},
+			},
+		},
+		{
+			name:     "빈 맵과 빈 배열",
+			document: map[string]any{"labels": map[string]any{}, "args": []any{}},
+			want: []canonicalLine{
+				{text: "args: []", path: "args"},
+				{text: "labels: {}", path: "labels"},
+			},
+		},
+		{
+			name:     "여러 줄 문자열",
+			document: map[string]any{"script": "a\nb\n"},
+			want: []canonicalLine{
+				{text: "script: |", path: "script"},
+				{text: "  a", path: "script"},
+				{text: "  b", path: "script"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, canonicalize(tt.document))
+		})
+	}
+}
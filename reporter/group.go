@@ -0,0 +1,201 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// GroupByPath 는 결과를 상위 경로의 계층 구조로 묶습니다.
+	GroupByPath domain.GroupBy = "path"
+	// GroupBySection 은 결과를 최상위 키로 묶습니다.
+	GroupBySection domain.GroupBy = "section"
+	GroupByCode    domain.GroupBy = "code"
+	// GroupByFile 은 결과를 값이 정의된 파일로 묶습니다. (--annotate-layers, --resolve-refs)
+	GroupByFile domain.GroupBy = "file"
+)
+
+const (
+	SortByCode     domain.SortBy = "code"
+	SortByPath     domain.SortBy = "path"
+	SortBySeverity domain.SortBy = "severity"
+	SortByFile     domain.SortBy = "file"
+)
+
+func NewGroupBy(groupBy string) (domain.GroupBy, error) {
+	switch domain.GroupBy(groupBy) {
+	case "", GroupByPath, GroupBySection, GroupByCode, GroupByFile:
+		return domain.GroupBy(groupBy), nil
+	default:
+		return "", fmt.Errorf("unsupported group by: %s", groupBy)
+	}
+}
+
+func NewSortBy(sortBy string) (domain.SortBy, error) {
+	switch domain.SortBy(sortBy) {
+	case "", SortByCode, SortByPath, SortBySeverity, SortByFile:
+		return domain.SortBy(sortBy), nil
+	default:
+		return "", fmt.Errorf("unsupported sort by: %s", sortBy)
+	}
+}
+
+// resultGroup 은 리포트에서 하나의 제목 아래 출력할 결과 묶음입니다. 이름이 없는 묶음은 제목 없이 출력합니다.
+type resultGroup struct {
+	name     string
+	results  domain.ErrorResults
+	children []resultGroup
+}
+
+func isGrouped(results domain.ErrorResults) bool {
+	return lo.ContainsBy(results, func(result domain.ErrorResult) bool {
+		return result.Group != ""
+	})
+}
+
+// groups 는 GroupBy 설정에 따라 결과를 묶습니다. 설정이 없으면 문서 그룹(Group)이 있는 경우에만 문서 그룹으로 묶으며,
+// 묶지 않는 경우 nil 을 반환합니다.
+func (r reporter) groups(results domain.ErrorResults) []resultGroup {
+	switch r.config.GroupBy {
+	case GroupByPath:
+		return pathGroups(results)
+	case GroupBySection:
+		return groupResults(results, func(result domain.ErrorResult) string {
+			return topLevelKey(result.Key)
+		})
+	case GroupByCode:
+		return groupResults(results, func(result domain.ErrorResult) string {
+			return string(result.ErrorCode)
+		})
+	case GroupByFile:
+		return groupResults(results, resultSource)
+	}
+
+	if !isGrouped(results) {
+		return nil
+	}
+
+	return groupResults(results, func(result domain.ErrorResult) string {
+		return result.Group
+	})
+}
+
+// groupResults 는 결과를 name 별로 묶어 이름 순으로 반환합니다. 이름이 없는 결과는 맨 앞에 둡니다.
+func groupResults(results domain.ErrorResults, name func(result domain.ErrorResult) string) []resultGroup {
+	grouped := lo.GroupBy(results, name)
+
+	names := lo.Keys(grouped)
+	sort.Strings(names)
+
+	return lo.Map(names, func(name string, _ int) resultGroup {
+		return resultGroup{name: name, results: grouped[name]}
+	})
+}
+
+// pathGroups 는 결과를 상위 경로의 계층 구조로 묶습니다. 각 묶음의 이름은 전체 경로이며,
+// 결과 없이 하위 묶음이 하나뿐인 경로는 하위 묶음과 합칩니다. ex. database → database.primary
+func pathGroups(results domain.ErrorResults) []resultGroup {
+	root := &resultGroup{}
+	for _, result := range results {
+		segments := domain.SplitPath(result.Key)
+
+		node, path := root, ""
+		for _, segment := range segments[:max(len(segments)-1, 0)] {
+			path = joinSegment(path, segment)
+			idx := lo.IndexOf(lo.Map(node.children, func(child resultGroup, _ int) string { return child.name }), path)
+			if idx < 0 {
+				node.children = append(node.children, resultGroup{name: path})
+				idx = len(node.children) - 1
+			}
+			node = &node.children[idx]
+		}
+		node.results = append(node.results, result)
+	}
+
+	groups := compactGroups(root.children)
+	if len(root.results) > 0 {
+		groups = append([]resultGroup{{results: root.results}}, groups...)
+	}
+
+	return groups
+}
+
+func compactGroups(groups []resultGroup) []resultGroup {
+	for idx := range groups {
+		for len(groups[idx].results) == 0 && len(groups[idx].children) == 1 {
+			groups[idx] = groups[idx].children[0]
+		}
+		groups[idx].children = compactGroups(groups[idx].children)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+
+	return groups
+}
+
+func joinSegment(path string, segment string) string {
+	if strings.HasPrefix(segment, "[") {
+		return path + segment
+	}
+
+	return domain.MapKey(path, segment)
+}
+
+func topLevelKey(key string) string {
+	if segments := domain.SplitPath(key); len(segments) > 0 {
+		return segments[0]
+	}
+
+	return ""
+}
+
+// resultSource 는 값이 정의된 파일을 반환합니다. 우측 파일을 우선합니다.
+func resultSource(result domain.ErrorResult) string {
+	if result.RHS.Source != "" {
+		return result.RHS.Source
+	}
+
+	return result.LHS.Source
+}
+
+// sortResults 는 SortBy 설정에 따라 정렬한 결과를 반환합니다. 같은 순위의 결과는 기존 순서를 유지합니다.
+func (r reporter) sortResults(results domain.ErrorResults) domain.ErrorResults {
+	var less func(lhs domain.ErrorResult, rhs domain.ErrorResult) bool
+	switch r.config.SortBy {
+	case SortByCode:
+		less = func(lhs domain.ErrorResult, rhs domain.ErrorResult) bool {
+			return lhs.ErrorCode < rhs.ErrorCode
+		}
+	case SortByPath:
+		less = func(lhs domain.ErrorResult, rhs domain.ErrorResult) bool {
+			return lhs.Key < rhs.Key
+		}
+	case SortBySeverity:
+		less = func(lhs domain.ErrorResult, rhs domain.ErrorResult) bool {
+			if lhs.Severity != rhs.Severity {
+				return !rhs.Severity.AtLeast(lhs.Severity)
+			}
+			return lhs.Key < rhs.Key
+		}
+	case SortByFile:
+		less = func(lhs domain.ErrorResult, rhs domain.ErrorResult) bool {
+			if resultSource(lhs) != resultSource(rhs) {
+				return resultSource(lhs) < resultSource(rhs)
+			}
+			return lhs.Key < rhs.Key
+		}
+	default:
+		return results
+	}
+
+	sorted := append(domain.ErrorResults(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+
+	return sorted
+}
@@ -0,0 +1,234 @@
+package reporter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testResults() domain.ErrorResults {
+	host := domain.ValueUnmatchedResult("database.primary.host", "a", "b")
+	host.RHS.Source = "values-prod.yaml"
+
+	port := domain.KeyNotFoundResult("database.primary.port", 5432, nil)
+	port.LHS.Source = "values.yaml"
+
+	replicas := domain.ValueUnmatchedResult("replicas", 1, 2)
+	replicas.Severity = domain.SeverityError
+
+	image := domain.TypeUnmatchedResult("app.image", "app:1", 1)
+	image.Group = "Deployment/app"
+
+	return domain.ErrorResults{host, port, replicas, image}
+}
+
+// groupTree 는 묶음을 "들여쓰기 + 이름: 결과 경로" 형식의 줄로 변환합니다.
+func groupTree(groups []resultGroup, indent string) []string {
+	var lines []string
+	for _, group := range groups {
+		keys := make([]string, 0, len(group.results))
+		for _, result := range group.results {
+			keys = append(keys, result.Key)
+		}
+
+		lines = append(lines, indent+group.name+": "+strings.Join(keys, ","))
+		lines = append(lines, groupTree(group.children, indent+"  ")...)
+	}
+
+	return lines
+}
+
+func Test_reporter_groups(t *testing.T) {
+	tests := []struct {
+		name    string
+		groupBy domain.GroupBy
+		results domain.ErrorResults
+		want    []string
+	}{
+		{
+			name:    "지정하지 않은 경우 문서 그룹",
+			results: testResults(),
+			want: []string{
+				": database.primary.host,database.primary.port,replicas",
+				"Deployment/app: app.image",
+			},
+		},
+		{
+			name:    "지정하지 않았고 문서 그룹이 없는 경우 묶지 않음",
+			results: testResults()[:3],
+			want:    nil,
+		},
+		{
+			name:    "path",
+			groupBy: GroupByPath,
+			results: testResults(),
+			want: []string{
+				": replicas",
+				"app: app.image",
+				"database.primary: database.primary.host,database.primary.port",
+			},
+		},
+		{
+			name:    "path 하위 묶음이 여러 개인 경로는 합치지 않음",
+			groupBy: GroupByPath,
+			results: append(testResults(), domain.ValueUnmatchedResult("database.replica.host", "c", "d")),
+			want: []string{
+				": replicas",
+				"app: app.image",
+				"database: ",
+				"  database.primary: database.primary.host,database.primary.port",
+				"  database.replica: database.replica.host",
+			},
+		},
+		{
+			name:    "section",
+			groupBy: GroupBySection,
+			results: testResults(),
+			want: []string{
+				"app: app.image",
+				"database: database.primary.host,database.primary.port",
+				"replicas: replicas",
+			},
+		},
+		{
+			name:    "code",
+			groupBy: GroupByCode,
+			results: testResults(),
+			want: []string{
+				"KEY_NOT_FOUND: database.primary.port",
+				"TYPE_UNMATCHED: app.image",
+				"VALUE_UNMATCHED: database.primary.host,replicas",
+			},
+		},
+		{
+			name:    "file",
+			groupBy: GroupByFile,
+			results: testResults(),
+			want: []string{
+				": replicas,app.image",
+				"values-prod.yaml: database.primary.host",
+				"values.yaml: database.primary.port",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := reporter{config: Config{GroupBy: tt.groupBy}}
+			assert.Equal(t, tt.want, groupTree(r.groups(tt.results), ""))
+		})
+	}
+}
+
+func Test_reporter_sortResults(t *testing.T) {
+	tests := []struct {
+		name   string
+		sortBy domain.SortBy
+		want   []string
+	}{
+		{
+			name: "지정하지 않은 경우 비교 순서 유지",
+			want: []string{"database.primary.host", "database.primary.port", "replicas", "app.image"},
+		},
+		{
+			name:   "code",
+			sortBy: SortByCode,
+			want:   []string{"database.primary.port", "app.image", "database.primary.host", "replicas"},
+		},
+		{
+			name:   "path",
+			sortBy: SortByPath,
+			want:   []string{"app.image", "database.primary.host", "database.primary.port", "replicas"},
+		},
+		{
+			name:   "severity",
+			sortBy: SortBySeverity,
+			want:   []string{"app.image", "replicas", "database.primary.host", "database.primary.port"},
+		},
+		{
+			name:   "file",
+			sortBy: SortByFile,
+			want:   []string{"app.image", "replicas", "database.primary.host", "database.primary.port"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := reporter{config: Config{SortBy: tt.sortBy}}
+
+			var got []string
+			for _, result := range r.sortResults(testResults()) {
+				got = append(got, result.Key)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_reporter_generateJsonReport_Groups(t *testing.T) {
+	r := New(Config{GroupBy: GroupByPath}).(reporter)
+
+	report, err := r.generateJsonReport(append(testResults(), domain.ValueUnmatchedResult("database.replica.host", "c", "d")))
+	assert.NoError(t, err)
+
+	var response domain.ReportResponse
+	assert.NoError(t, json.Unmarshal([]byte(report), &response))
+	assert.Empty(t, response.Reports)
+
+	type node struct {
+		Name   string
+		Keys   []string
+		Groups []node
+	}
+	var convert func(groups []domain.ReportGroup) []node
+	convert = func(groups []domain.ReportGroup) []node {
+		var nodes []node
+		for _, group := range groups {
+			n := node{Name: group.Name, Groups: convert(group.Groups)}
+			for _, report := range group.Reports {
+				n.Keys = append(n.Keys, report.Key)
+			}
+			nodes = append(nodes, n)
+		}
+		return nodes
+	}
+
+	assert.Equal(t, []node{
+		{Name: "", Keys: []string{"replicas"}},
+		{Name: "app", Keys: []string{"app.image"}},
+		{Name: "database", Groups: []node{
+			{Name: "database.primary", Keys: []string{"database.primary.host", "database.primary.port"}},
+			{Name: "database.replica", Keys: []string{"database.replica.host"}},
+		}},
+	}, convert(response.Groups))
+}
+
+func Test_reporter_generateGroupTrees(t *testing.T) {
+	r := New(Config{GroupBy: GroupByPath, LHSAlias: "lhs", RHSAlias: "rhs"}).(reporter)
+	groups := r.groups(append(testResults()[:2], domain.ValueUnmatchedResult("database.replica.host", "c", "d")))
+
+	plainText, err := r.generatePlainTextTree(groups, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `[database]
+  [database.primary]
+  - (warning) [database.primary.host]Value unmatched. lhs: (string)a, rhs: (string)b (rhs: values-prod.yaml)
+  - (warning) Key not found in rhs. key:[database.primary.port] (lhs: values.yaml)
+  [database.replica]
+  - (warning) [database.replica.host]Value unmatched. lhs: (string)c, rhs: (string)d
+`, plainText)
+
+	markdown, err := r.generateMarkdownTree(groups, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "### `database`\n\n"+
+		"#### `database.primary`\n\n"+
+		"| Key | Error Code | Severity | lhs | rhs | Description |\n"+
+		"| --- | --- | --- | --- | --- | --- |\n"+
+		"| `database.primary.host` | `VALUE_UNMATCHED` | warning | `(string)a` | `(string)b`<br>_values-prod.yaml_ | Value unmatched. |\n"+
+		"| `database.primary.port` | `KEY_NOT_FOUND` | warning | `(int)5432`<br>_values.yaml_ | `(null)null` | Key not found. |\n\n"+
+		"#### `database.replica`\n\n"+
+		"| Key | Error Code | Severity | lhs | rhs | Description |\n"+
+		"| --- | --- | --- | --- | --- | --- |\n"+
+		"| `database.replica.host` | `VALUE_UNMATCHED` | warning | `(string)c` | `(string)d` | Value unmatched. |\n\n", markdown)
+}
@@ -0,0 +1,226 @@
+package reporter
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/samber/lo"
+)
+
+// htmlNode 는 문서 구조를 따르는 트리의 한 경로 세그먼트입니다.
+type htmlNode struct {
+	Name     string
+	Children []*htmlNode
+	Results  []reportEntry
+}
+
+// htmlSection 은 파일(또는 문서 그룹)별 섹션입니다.
+type htmlSection struct {
+	Name  string
+	Count int
+	Root  *htmlNode
+}
+
+type htmlReport struct {
+	LHSAlias  string
+	RHSAlias  string
+	BaseAlias string
+	Language  domain.ReportLanguage
+	Codes     []domain.ErrorCode
+	Sections  []htmlSection
+	Total     int
+}
+
+// generateHTMLReport 는 외부 리소스 없이 열람할 수 있는 HTML 리포트를 생성합니다.
+// 결과는 섹션(문서 그룹, 없으면 값이 정의된 파일)별로 나뉘며, 각 섹션은 문서 구조를 따르는 접을 수 있는 트리로 표시됩니다.
+func (r reporter) generateHTMLReport(results domain.ErrorResults) (string, error) {
+	items, err := r.reportEntries(results)
+	if err != nil {
+		return "", err
+	}
+
+	grouped := lo.GroupBy(items, htmlSectionName)
+	names := lo.Keys(grouped)
+	sort.Strings(names)
+
+	report := htmlReport{
+		LHSAlias:  r.config.LHSAlias,
+		RHSAlias:  r.config.RHSAlias,
+		BaseAlias: r.config.BaseAlias,
+		Language:  r.config.Language,
+		Codes:     lo.Uniq(lo.Map(items, func(item reportEntry, _ int) domain.ErrorCode { return item.ErrorCode })),
+		Total:     len(items),
+	}
+	for _, name := range names {
+		report.Sections = append(report.Sections, htmlSection{
+			Name:  name,
+			Count: len(grouped[name]),
+			Root:  buildHTMLTree(grouped[name]),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err = htmlTemplate.Execute(&buf, report); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// htmlSectionName 은 결과가 속할 섹션 이름을 반환합니다. 문서 그룹이 없으면 값이 정의된 파일을 사용합니다.
+func htmlSectionName(item reportEntry) string {
+	switch {
+	case item.Group != "":
+		return item.Group
+	case item.RHSSource != "":
+		return item.RHSSource
+	default:
+		return item.LHSSource
+	}
+}
+
+// buildHTMLTree 는 결과를 키 경로에 따라 트리로 만듭니다. 문서 전체(키 "")에 대한 결과는 root 에 기록됩니다.
+func buildHTMLTree(items []reportEntry) *htmlNode {
+	root := &htmlNode{}
+	for _, item := range items {
+		node := root
+		for _, segment := range domain.SplitPath(item.Key) {
+			child, ok := lo.Find(node.Children, func(child *htmlNode) bool {
+				return child.Name == segment
+			})
+			if !ok {
+				child = &htmlNode{Name: segment}
+				node.Children = append(node.Children, child)
+			}
+			node = child
+		}
+		node.Results = append(node.Results, item)
+	}
+
+	return root
+}
+
+// htmlFuncs 의 dict 는 하위 템플릿에 리포트 전체(root)와 현재 값(node), 추가 키-값 쌍을 함께 전달합니다.
+var htmlFuncs = template.FuncMap{
+	"dict": func(root htmlReport, node any, pairs ...string) map[string]any {
+		values := map[string]any{"root": root, "node": node}
+		for idx := 0; idx+1 < len(pairs); idx += 2 {
+			values[pairs[idx]] = pairs[idx+1]
+		}
+		return values
+	},
+	"subtree":      subtreeYAML,
+	"subtreeLabel": subtreeLabel,
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<title>Difference Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", sans-serif; margin: 24px; color: #1f2328; }
+h1 { font-size: 20px; }
+h2 { font-size: 16px; margin: 24px 0 8px; }
+.toolbar { display: flex; flex-wrap: wrap; gap: 12px; align-items: center; padding: 12px; background: #f6f8fa; border-radius: 6px; }
+.toolbar input[type=search] { padding: 4px 8px; min-width: 240px; }
+details { margin-left: 16px; }
+summary { cursor: pointer; font-family: monospace; padding: 2px 0; }
+.count { color: #656d76; font-size: 12px; }
+table { border-collapse: collapse; width: 100%; margin: 4px 0 8px 16px; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; font-size: 13px; }
+th { background: #f6f8fa; width: 50%; }
+td.value { font-family: monospace; white-space: pre-wrap; word-break: break-all; }
+.lhs { background: #ffebe9; }
+.rhs { background: #dafbe1; }
+.missing { color: #8c959f; font-style: italic; }
+.source { display: block; color: #656d76; font-style: italic; }
+.meta { margin-left: 16px; font-size: 13px; }
+.code { font-family: monospace; font-weight: bold; }
+.severity-critical, .severity-error { color: #cf222e; }
+.severity-warning { color: #9a6700; }
+.severity-info { color: #0969da; }
+.hidden { display: none; }
+</style>
+</head>
+<body>
+<h1>Difference Report <span class="count">({{.Total}})</span></h1>
+<div class="toolbar">
+<input type="search" id="search" placeholder="Search keys and values">
+{{- range .Codes}}
+<label><input type="checkbox" class="code-filter" value="{{.}}" checked> <span class="code">{{.}}</span></label>
+{{- end}}
+</div>
+{{- $root := .}}
+{{- range .Sections}}
+<section>
+{{- if .Name}}
+<h2>{{.Name}} <span class="count">({{.Count}})</span></h2>
+{{- end}}
+{{- template "results" dict $root .Root}}
+{{template "node" dict $root .Root}}
+</section>
+{{- end}}
+<script>
+(function () {
+  var search = document.getElementById("search");
+  var filters = Array.prototype.slice.call(document.querySelectorAll(".code-filter"));
+  function apply() {
+    var query = search.value.toLowerCase();
+    var codes = filters.filter(function (f) { return f.checked; }).map(function (f) { return f.value; });
+    document.querySelectorAll(".result").forEach(function (row) {
+      var visible = codes.indexOf(row.dataset.code) >= 0 && row.textContent.toLowerCase().indexOf(query) >= 0;
+      row.classList.toggle("hidden", !visible);
+    });
+    var nodes = Array.prototype.slice.call(document.querySelectorAll("details")).reverse();
+    nodes.forEach(function (node) {
+      node.classList.toggle("hidden", node.querySelector(".result:not(.hidden)") === null);
+    });
+    document.querySelectorAll("section").forEach(function (section) {
+      section.classList.toggle("hidden", section.querySelector(".result:not(.hidden)") === null);
+    });
+  }
+  search.addEventListener("input", apply);
+  filters.forEach(function (f) { f.addEventListener("change", apply); });
+})();
+</script>
+</body>
+</html>
+{{define "node"}}
+{{- $root := index . "root"}}
+{{- range (index . "node").Children}}
+<details open>
+<summary>{{.Name}}</summary>
+{{- template "results" dict $root .}}
+{{template "node" dict $root .}}
+</details>
+{{- end}}
+{{- end}}
+{{define "results"}}
+{{- $root := index . "root"}}
+{{- range (index . "node").Results}}
+<div class="result" data-code="{{.ErrorCode}}">
+<div class="meta"><span class="code">{{.ErrorCode}}</span> <span class="severity-{{.Severity}}">{{.Severity}}</span> <code>{{.Key}}</code> {{.Description}}</div>
+<table>
+<tr>{{if .Base.Type}}<th>{{$root.BaseAlias}}</th>{{end}}<th>{{$root.LHSAlias}}</th><th>{{$root.RHSAlias}}</th></tr>
+<tr>
+{{- if .Base.Type}}{{template "entry" dict $root .Base "class" ""}}{{end}}
+{{- template "entry" dict $root .LHS "class" "lhs"}}
+{{- template "entry" dict $root .RHS "class" "rhs"}}
+</tr>
+</table>
+</div>
+{{- end}}
+{{- end}}
+{{define "entry"}}
+{{- $entry := index . "node"}}
+{{- if or (not $entry.Type) (eq $entry.Type "null")}}<td class="value missing">-</td>
+{{- else if $entry.Subtree}}<td class="value {{index . "class"}}">{{subtreeLabel $entry}}
+{{subtree $entry.Subtree}}{{if $entry.Source}}<span class="source">{{$entry.Source}}</span>{{end}}</td>
+{{- else}}<td class="value {{index . "class"}}">({{$entry.Type}}) {{$entry.Value}}{{if $entry.Source}}<span class="source">{{$entry.Source}}</span>{{end}}</td>
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,180 @@
+package reporter
+
+type editKind int
+
+const (
+	editEqual editKind = iota
+	editDelete
+	editInsert
+)
+
+// edit 은 줄 단위 비교 결과의 한 단계입니다. lhs, rhs 는 각 문서의 줄 번호(0부터)이며 해당 쪽에 줄이 없으면 -1 입니다.
+type edit struct {
+	kind editKind
+	lhs  int
+	rhs  int
+}
+
+// diffLines 는 Myers 알고리즘으로 두 줄 목록의 최소 편집 스크립트를 구합니다.
+func diffLines(lhs []canonicalLine, rhs []canonicalLine) []edit {
+	n, m := len(lhs), len(rhs)
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && lhs[x].text == rhs[y].text {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, offset, n, m, d)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backtrack 은 각 단계의 상태(trace)를 거꾸로 따라가며 편집 스크립트를 만듭니다.
+func backtrack(trace [][]int, offset int, n int, m int, depth int) []edit {
+	var edits []edit
+	x, y := n, m
+	for d := depth; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, edit{kind: editEqual, lhs: x, rhs: y})
+		}
+		if d == 0 {
+			break
+		}
+
+		if x == prevX {
+			edits = append(edits, edit{kind: editInsert, lhs: -1, rhs: prevY})
+		} else {
+			edits = append(edits, edit{kind: editDelete, lhs: prevX, rhs: -1})
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
+
+// hunk 는 변경된 줄과 앞뒤 문맥 줄을 묶은 편집 구간입니다.
+type hunk struct {
+	// start 는 구간의 첫 편집 단계가 전체 편집 스크립트에서 차지하는 위치입니다.
+	start int
+	edits []edit
+}
+
+// hunks 는 편집 스크립트를 변경 앞뒤로 context 줄의 문맥을 포함한 구간으로 나눕니다.
+// 변경 사이의 같은 줄이 context*2 줄 이하인 경우 하나의 구간으로 합칩니다.
+// include 가 지정된 경우 include 가 true 를 반환하는 변경만 구간의 기준으로 삼으며, 다른 변경은 문맥 안에 있을 때만 포함됩니다.
+func hunks(edits []edit, context int, include func(edit) bool) []hunk {
+	var (
+		result []hunk
+		start  = -1
+		end    = -1
+	)
+
+	flush := func() {
+		if start < 0 {
+			return
+		}
+
+		from, to := start, end
+		for count := 0; from > 0 && count < context; from-- {
+			if edits[from-1].kind == editEqual {
+				count++
+			}
+		}
+		for count := 0; to < len(edits)-1 && count < context; to++ {
+			if edits[to+1].kind == editEqual {
+				count++
+			}
+		}
+		result = append(result, hunk{start: from, edits: edits[from : to+1]})
+		start, end = -1, -1
+	}
+
+	// equals 는 직전 변경 이후의 같은 줄 수로, 구간에 포함되지 않는 변경은 구간을 나누는 기준에서 제외합니다.
+	equals := 0
+	for idx, e := range edits {
+		if e.kind == editEqual {
+			equals++
+			continue
+		}
+		if include != nil && !include(e) {
+			continue
+		}
+
+		if start >= 0 && equals > context*2 {
+			flush()
+		}
+		if start < 0 {
+			start = idx
+		}
+		end, equals = idx, 0
+	}
+	flush()
+
+	return result
+}
+
+// lineRange 는 구간이 각 문서에서 차지하는 시작 줄(1부터)과 줄 수를 반환합니다.
+// 한쪽 문서에 줄이 없는 경우 unified diff 관례에 따라 구간 직전 줄을 시작 줄로 반환합니다.
+func (h hunk) lineRange(edits []edit) (lhsStart int, lhsCount int, rhsStart int, rhsCount int) {
+	for _, e := range edits[:h.start] {
+		if e.lhs >= 0 {
+			lhsStart++
+		}
+		if e.rhs >= 0 {
+			rhsStart++
+		}
+	}
+
+	for _, e := range h.edits {
+		if e.lhs >= 0 {
+			lhsCount++
+		}
+		if e.rhs >= 0 {
+			rhsCount++
+		}
+	}
+
+	if lhsCount > 0 {
+		lhsStart++
+	}
+	if rhsCount > 0 {
+		rhsStart++
+	}
+
+	return lhsStart, lhsCount, rhsStart, rhsCount
+}
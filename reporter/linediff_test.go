@@ -0,0 +1,147 @@
+package reporter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func textLines(texts ...string) []canonicalLine {
+	lines := make([]canonicalLine, 0, len(texts))
+	for _, text := range texts {
+		lines = append(lines, canonicalLine{text: text})
+	}
+
+	return lines
+}
+
+func Test_diffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		lhs  []canonicalLine
+		rhs  []canonicalLine
+		want []edit
+	}{
+		{
+			name: "같은 줄",
+			lhs:  textLines("a", "b"),
+			rhs:  textLines("a", "b"),
+			want: []edit{
+				{kind: editEqual, lhs: 0, rhs: 0},
+				{kind: editEqual, lhs: 1, rhs: 1},
+			},
+		},
+		{
+			name: "줄 추가",
+			lhs:  textLines("a", "c"),
+			rhs:  textLines("a", "b", "c"),
+			want: []edit{
+				{kind: editEqual, lhs: 0, rhs: 0},
+				{kind: editInsert, lhs: -1, rhs: 1},
+				{kind: editEqual, lhs: 1, rhs: 2},
+			},
+		},
+		{
+			name: "줄 삭제",
+			lhs:  textLines("a", "b", "c"),
+			rhs:  textLines("a", "c"),
+			want: []edit{
+				{kind: editEqual, lhs: 0, rhs: 0},
+				{kind: editDelete, lhs: 1, rhs: -1},
+				{kind: editEqual, lhs: 2, rhs: 1},
+			},
+		},
+		{
+			name: "줄 변경",
+			lhs:  textLines("a", "b", "c"),
+			rhs:  textLines("a", "x", "c"),
+			want: []edit{
+				{kind: editEqual, lhs: 0, rhs: 0},
+				{kind: editDelete, lhs: 1, rhs: -1},
+				{kind: editInsert, lhs: -1, rhs: 1},
+				{kind: editEqual, lhs: 2, rhs: 2},
+			},
+		},
+		{
+			name: "빈 문서에 추가",
+			lhs:  nil,
+			rhs:  textLines("a"),
+			want: []edit{
+				{kind: editInsert, lhs: -1, rhs: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffLines(tt.lhs, tt.rhs))
+		})
+	}
+}
+
+func Test_hunks(t *testing.T) {
+	tests := []struct {
+		name    string
+		lhs     []canonicalLine
+		rhs     []canonicalLine
+		context int
+		include func(edit) bool
+		want    []string
+	}{
+		{
+			name:    "변경 앞뒤 문맥 포함",
+			lhs:     textLines("a", "b", "c", "d", "e"),
+			rhs:     textLines("a", "b", "x", "d", "e"),
+			context: 1,
+			want:    []string{"-2,3 +2,3"},
+		},
+		{
+			name:    "끝에 줄 추가",
+			lhs:     textLines("a"),
+			rhs:     textLines("a", "b"),
+			context: 0,
+			want:    []string{"-1,0 +2,1"},
+		},
+		{
+			name:    "처음 줄 삭제",
+			lhs:     textLines("a", "b"),
+			rhs:     textLines("b"),
+			context: 0,
+			want:    []string{"-1,1 +0,0"},
+		},
+		{
+			name:    "문맥이 겹치는 변경은 하나의 구간",
+			lhs:     textLines("a", "b", "c", "d", "e"),
+			rhs:     textLines("x", "b", "c", "y", "e"),
+			context: 1,
+			want:    []string{"-1,5 +1,5"},
+		},
+		{
+			name:    "멀리 떨어진 변경은 별도의 구간",
+			lhs:     textLines("a", "b", "c", "d", "e", "f", "g"),
+			rhs:     textLines("x", "b", "c", "d", "e", "f", "y"),
+			context: 1,
+			want:    []string{"-1,2 +1,2", "-6,2 +6,2"},
+		},
+		{
+			name:    "include 에 해당하지 않는 변경은 구간의 기준에서 제외",
+			lhs:     textLines("a", "b", "c", "d", "e"),
+			rhs:     textLines("a", "x", "c", "d", "y"),
+			context: 1,
+			include: func(e edit) bool { return e.lhs == 1 || e.rhs == 1 },
+			want:    []string{"-1,3 +1,3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := diffLines(tt.lhs, tt.rhs)
+
+			var got []string
+			for _, h := range hunks(edits, tt.context, tt.include) {
+				lhsStart, lhsCount, rhsStart, rhsCount := h.lineRange(edits)
+				got = append(got, fmt.Sprintf("-%d,%d +%d,%d", lhsStart, lhsCount, rhsStart, rhsCount))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
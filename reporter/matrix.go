@@ -0,0 +1,134 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/catalog"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+// MatrixFormats 는 ReportMatrix 가 지원하는 리포트 형식입니다.
+var MatrixFormats = []domain.ReportFormat{JSON, Markdown, Plain}
+
+func (r reporter) ReportMatrix(matrix domain.Matrix) error {
+	var (
+		report string
+		err    error
+	)
+
+	if matrix.IsEmpty() {
+		fmt.Println("No differences found")
+		return nil
+	}
+
+	switch r.config.Format {
+	case JSON:
+		report, err = r.generateJsonMatrixReport(matrix)
+		if err != nil {
+			return err
+		}
+	case Markdown:
+		report = r.generateMarkdownMatrixReport(matrix)
+	case Plain:
+		report = r.generatePlainTextMatrixReport(matrix)
+	default:
+		return errors.New("unsupported report mode")
+	}
+
+	return r.output(report)
+}
+
+func (r reporter) generateJsonMatrixReport(matrix domain.Matrix) (string, error) {
+	rows := make([]domain.MatrixRowReport, 0, len(matrix.Rows))
+	for _, row := range matrix.Rows {
+		values := make([]domain.MatrixValueReport, 0, len(row.Cells))
+		for idx, cell := range row.Cells {
+			values = append(values, domain.MatrixValueReport{
+				Alias:    r.alias(idx),
+				Type:     cell.Entry.Type,
+				Value:    cell.Entry.Value,
+				Present:  cell.Present,
+				Deviates: cell.Deviates,
+			})
+		}
+
+		rows = append(rows, domain.MatrixRowReport{Key: row.Key, Values: values})
+	}
+
+	reportJson, err := json.Marshal(domain.MatrixReportResponse{
+		Aliases:   r.config.Aliases,
+		Reference: matrix.Reference,
+		Rows:      rows,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(reportJson), nil
+}
+
+func (r reporter) generateMarkdownMatrixReport(matrix domain.Matrix) string {
+	report := "## Environment Matrix Report\n\n"
+
+	description := r.messages.Message("matrixMajority", nil)
+	if matrix.Reference != "" {
+		description = r.messages.Message("matrixReference", catalog.Args{"reference": matrix.Reference})
+	}
+	report += description + "\n\n"
+
+	report += "| Key |"
+	for idx := range r.config.Aliases {
+		report += fmt.Sprintf(" %s |", r.alias(idx))
+	}
+	report += "\n|" + strings.Repeat(" --- |", len(r.config.Aliases)+1) + "\n"
+
+	for _, row := range matrix.Rows {
+		report += fmt.Sprintf("| `%s` |", row.Key)
+		for _, cell := range row.Cells {
+			value := "-"
+			if cell.Present {
+				value = markdownEntry(cell.Entry)
+			}
+			if cell.Deviates {
+				value += " ⚠️"
+			}
+			report += fmt.Sprintf(" %s |", value)
+		}
+		report += "\n"
+	}
+
+	return report
+}
+
+func (r reporter) generatePlainTextMatrixReport(matrix domain.Matrix) string {
+	plainText := ""
+
+	for _, row := range matrix.Rows {
+		values := make([]string, 0, len(row.Cells))
+		for idx, cell := range row.Cells {
+			value := "(none)"
+			if cell.Present {
+				value = fmt.Sprintf("(%s)%s", cell.Entry.Type, cell.Entry.Value)
+			}
+			if cell.Deviates {
+				value += " (!)"
+			}
+			values = append(values, fmt.Sprintf("%s: %s", r.alias(idx), value))
+		}
+
+		plainText += fmt.Sprintf("- [%s] %s\n", row.Key, strings.Join(values, ", "))
+	}
+
+	return plainText
+}
+
+func (r reporter) alias(idx int) string {
+	if idx < len(r.config.Aliases) {
+		return r.config.Aliases[idx]
+	}
+
+	return fmt.Sprintf("#%d", idx+1)
+}
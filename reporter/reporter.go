@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"os"
 	"path"
+	"strings"
 
+	"github.com/illuminarean-labs/yaml-diff-reporter/catalog"
 	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
 
 	"github.com/samber/lo"
@@ -16,37 +19,69 @@ const (
 	JSON     domain.ReportFormat = "json"
 	Markdown domain.ReportFormat = "markdown"
 	Plain    domain.ReportFormat = "plain"
-)
-
-const (
-	EN domain.ReportLanguage = "en"
-	KO domain.ReportLanguage = "ko"
+	HTML     domain.ReportFormat = "html"
+	Terminal domain.ReportFormat = "terminal"
+	Unified  domain.ReportFormat = "unified"
 )
 
 const (
 	Stdout domain.ReportOutputType = "stdout"
+	Stderr domain.ReportOutputType = "stderr"
 	File   domain.ReportOutputType = "file"
 )
 
 type Reporter interface {
 	Report(results domain.ErrorResults) error
+	ReportMatrix(matrix domain.Matrix) error
 }
 
 type Config struct {
-	Format     domain.ReportFormat
-	Language   domain.ReportLanguage
-	LHSAlias   string
-	RHSAlias   string
+	Format    domain.ReportFormat
+	Language  domain.ReportLanguage
+	LHSAlias  string
+	RHSAlias  string
+	BaseAlias string
+	// Aliases 는 N-way 비교에서 입력 순서대로의 별칭입니다.
+	Aliases    []string
 	OutputPath *string
 	OutputType domain.ReportOutputType
+
+	// LHSDocument, RHSDocument 는 비교한 문서로, 문서를 다시 직렬화하여 비교하는 terminal, unified 형식에서 사용합니다.
+	LHSDocument map[string]any
+	RHSDocument map[string]any
+	// Catalog 는 리포트 메시지 카탈로그입니다. 지정하지 않은 경우 Language 의 내장 카탈로그를 사용합니다.
+	Catalog catalog.Catalog
+	// Stats 는 비교 통계로, 요약에 함께 출력합니다.
+	Stats *domain.CompareStats
+	// SummaryOnly 가 true 인 경우 결과 목록 없이 요약만 출력합니다. json, markdown, plain 형식에서 지원합니다.
+	SummaryOnly bool
+	// GroupBy 는 json, markdown, plain 형식에서 결과를 묶는 기준입니다. 지정하지 않은 경우 문서 그룹으로 묶습니다.
+	GroupBy domain.GroupBy
+	// SortBy 는 결과의 정렬 기준입니다. 지정하지 않은 경우 비교 결과의 순서를 유지합니다.
+	SortBy domain.SortBy
+	// TemplatePath 가 지정된 경우 Format 대신 해당 Go 템플릿 파일로 리포트를 생성합니다.
+	TemplatePath string
+	// View 는 terminal 형식의 표시 방식입니다.
+	View domain.DiffView
+	// Context 는 terminal, unified 형식에서 변경 앞뒤로 보여줄 변경되지 않은 줄 수입니다.
+	Context int
 }
 
 type reporter struct {
-	config Config
+	config   Config
+	messages catalog.Catalog
+	// color 는 terminal 형식에서 ANSI 색상을 사용할지 여부입니다.
+	color bool
 }
 
 func New(config Config) Reporter {
-	return reporter{config: config}
+	r := reporter{config: config, messages: config.Catalog}
+	if r.messages == nil {
+		r.messages = catalog.Default(config.Language)
+	}
+	r.color = r.colorEnabled()
+
+	return r
 }
 
 func (r reporter) Report(results domain.ErrorResults) error {
@@ -55,11 +90,26 @@ func (r reporter) Report(results domain.ErrorResults) error {
 		err    error
 	)
 
-	if len(results) == 0 {
+	if len(results) == 0 && !r.config.SummaryOnly {
 		fmt.Println("No differences found")
 		return nil
 	}
 
+	results = r.sortResults(results)
+
+	if r.config.TemplatePath != "" {
+		report, err = r.generateTemplateReport(results)
+		if err != nil {
+			return err
+		}
+
+		return r.output(report)
+	}
+
+	if r.config.SummaryOnly && !lo.Contains([]domain.ReportFormat{JSON, Markdown, Plain}, r.config.Format) {
+		return fmt.Errorf("summary only is not supported in %s format", r.config.Format)
+	}
+
 	switch r.config.Format {
 	case JSON:
 		report, err = r.generateJsonReport(results)
@@ -76,15 +126,36 @@ func (r reporter) Report(results domain.ErrorResults) error {
 		if err != nil {
 			return err
 		}
+	case HTML:
+		report, err = r.generateHTMLReport(results)
+		if err != nil {
+			return err
+		}
+	case Terminal:
+		report, err = r.generateTerminalReport(results)
+		if err != nil {
+			return err
+		}
+	case Unified:
+		report, err = r.generateUnifiedReport(results)
+		if err != nil {
+			return err
+		}
 	default:
 		return errors.New("unsupported report mode")
 	}
 
+	return r.output(report)
+}
+
+func (r reporter) output(report string) error {
 	switch r.config.OutputType {
 	case Stdout:
 		r.printReport(report)
+	case Stderr:
+		fmt.Fprintln(os.Stderr, report)
 	case File:
-		if err = r.writeReportFile(report); err != nil {
+		if err := r.writeReportFile(report); err != nil {
 			return err
 		}
 	default:
@@ -94,190 +165,405 @@ func (r reporter) Report(results domain.ErrorResults) error {
 	return nil
 }
 
+// generatePlainTextReport 는 결과 목록 뒤에 요약을 출력합니다.
 func (r reporter) generatePlainTextReport(results domain.ErrorResults) (string, error) {
+	summary := r.generatePlainTextSummary(results)
+	if r.config.SummaryOnly {
+		return summary, nil
+	}
+
+	lines, err := r.generatePlainTextGroups(results)
+	if err != nil {
+		return "", err
+	}
+
+	return lines + summary, nil
+}
+
+func (r reporter) generatePlainTextGroups(results domain.ErrorResults) (string, error) {
+	groups := r.groups(results)
+	if groups == nil {
+		return r.generatePlainTextLines(results, "")
+	}
+
+	return r.generatePlainTextTree(groups, "")
+}
+
+// generatePlainTextTree 는 묶음의 제목과 결과를 같은 들여쓰기로, 하위 묶음은 한 단계 더 들여써서 출력합니다.
+func (r reporter) generatePlainTextTree(groups []resultGroup, indent string) (string, error) {
 	plainText := ""
+	for _, group := range groups {
+		lines, err := r.generatePlainTextLines(group.results, indent)
+		if err != nil {
+			return "", err
+		}
 
-	var descriptionMap map[domain.ReportLanguage]string
-	for _, result := range results {
+		children, err := r.generatePlainTextTree(group.children, indent+"  ")
+		if err != nil {
+			return "", err
+		}
 
-		switch result.ErrorCode {
-		case domain.ErrorTypeUnmatched:
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("- [%s]키의 타입이 일치하지 않습니다. %s: %s, %s: %s\n", result.Key, r.config.LHSAlias, result.LHS.Type, r.config.RHSAlias, result.RHS.Type),
-				EN: fmt.Sprintf("- [%s]Type unmatched. %s: %s, %s: %s\n", result.Key, r.config.LHSAlias, result.LHS.Type, r.config.RHSAlias, result.RHS.Type),
-			}
-		case domain.ErrorValueUnmatched:
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("- [%s]키의 값이 일치하지 않습니다. %s: (%s)%s, %s: (%s)%s\n", result.Key, r.config.LHSAlias, result.LHS.Type, result.LHS.Value, r.config.RHSAlias, result.RHS.Type, result.RHS.Value),
-				EN: fmt.Sprintf("- [%s]Value unmatched. %s: (%s)%s, %s: (%s)%s\n", result.Key, r.config.LHSAlias, result.LHS.Type, result.LHS.Value, r.config.RHSAlias, result.RHS.Type, result.RHS.Value),
-			}
-		case domain.ErrorKeyNotFound:
-			var sideAlias string
-			if result.FindNilSide() == "LHS" {
-				sideAlias = r.config.LHSAlias
-			} else {
-				sideAlias = r.config.RHSAlias
-			}
+		if group.name != "" {
+			plainText += fmt.Sprintf("%s[%s]\n", indent, group.name)
+		}
+		plainText += lines + children
+	}
 
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("- %s에서 [%s]키가 존재하지 않습니다.\n", sideAlias, result.Key),
-				EN: fmt.Sprintf("- Key not found in %s. key:[%s]\n", sideAlias, result.Key),
-			}
-		case domain.ErrorIndexNotFound:
-			var sideAlias string
-			if result.FindNilSide() == "LHS" {
-				sideAlias = r.config.LHSAlias
-			} else {
-				sideAlias = r.config.RHSAlias
-			}
+	return plainText, nil
+}
 
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("- %s에서 [%s]인덱스가 존재하지 않습니다.\n", sideAlias, result.Key),
-				EN: fmt.Sprintf("- Index not found in %s. [%s]\n", sideAlias, result.Key),
-			}
-		default:
-			return "", errors.New("unsupported error code")
+func (r reporter) generatePlainTextLines(results domain.ErrorResults, indent string) (string, error) {
+	plainText := ""
+
+	for _, result := range results {
+		description, err := r.messages.Code(result.ErrorCode, catalog.Line, r.messageArgs(result))
+		if err != nil {
+			return "", err
 		}
 
-		plainText += descriptionMap[r.config.Language]
+		entry, subtree := result.SubtreeEntry()
+		if subtree {
+			description += " " + subtreeLabel(entry)
+		}
+
+		plainText += fmt.Sprintf("%s- (%s) %s%s\n", indent, result.Severity, description, r.sourceSuffix(result))
+		if subtree {
+			for _, line := range strings.Split(subtreeYAML(entry.Subtree), "\n") {
+				plainText += indent + "    " + line + "\n"
+			}
+		}
 	}
 
 	return plainText, nil
 }
 
 func (r reporter) generateJsonReport(results domain.ErrorResults) (string, error) {
+	response := domain.ReportResponse{Summary: results.Summarize(r.config.Stats)}
+	if !r.config.SummaryOnly && r.config.GroupBy != "" {
+		groups, err := r.reportGroups(r.groups(results))
+		if err != nil {
+			return "", err
+		}
+		response.Groups = groups
+	} else if !r.config.SummaryOnly {
+		reports, err := r.reports(results)
+		if err != nil {
+			return "", err
+		}
+		response.Reports = reports
+	}
+
+	reportJson, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+
+	return string(reportJson), nil
+}
+
+// reports 는 각 결과를 설명이 포함된 리포트 항목으로 변환합니다. json, html 형식에서 사용합니다.
+func (r reporter) reports(results domain.ErrorResults) ([]domain.Report, error) {
 	reports := make([]domain.Report, 0, len(results))
 
 	for _, result := range results {
-		switch result.ErrorCode {
-		case domain.ErrorTypeUnmatched:
-			DescriptionMap := map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("타입이 일치하지 않습니다. %s: %s, %s: %s",
-					r.config.LHSAlias, result.LHS.Type,
-					r.config.RHSAlias, result.RHS.Type,
-				),
-				EN: fmt.Sprintf("Type unmatched. %s: %s, %s: %s",
-					r.config.LHSAlias, result.LHS.Type,
-					r.config.RHSAlias, result.RHS.Type,
-				),
-			}
+		description, err := r.messages.Code(result.ErrorCode, catalog.Description, r.messageArgs(result))
+		if err != nil {
+			return nil, err
+		}
 
-			reports = append(reports, domain.Report{
-				Key:         result.Key,
-				ErrorCode:   result.ErrorCode,
-				Description: DescriptionMap[r.config.Language],
-			})
-		case domain.ErrorValueUnmatched:
-			DescriptionMap := map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("값이 일치하지 않습니다. %s: (%s)%s, %s: (%s)%s",
-					r.config.LHSAlias, result.LHS.Type, result.LHS.Value,
-					r.config.RHSAlias, result.RHS.Type, result.RHS.Value,
-				),
-				EN: fmt.Sprintf("Value unmatched. %s: (%s)%s, %s: (%s)%s",
-					r.config.LHSAlias, result.LHS.Type, result.LHS.Value,
-					r.config.RHSAlias, result.RHS.Type, result.RHS.Value,
-				),
-			}
+		report := domain.Report{
+			Key:         result.Key,
+			ErrorCode:   result.ErrorCode,
+			Severity:    result.Severity,
+			Description: description,
+		}
+		// baseline, 3-way 결과는 값이 정의된 파일을 표시하지 않습니다.
+		if !result.IsThreeWay() && result.ErrorCode != domain.ErrorBaselineStale {
+			report.LHSSource = result.LHS.Source
+			report.RHSSource = result.RHS.Source
+		}
+		if entry, ok := result.SubtreeEntry(); ok {
+			report.Subtree, report.KeyCount = entry.Subtree, entry.KeyCount
+		}
 
-			reports = append(reports, domain.Report{
-				Key:         result.Key,
-				ErrorCode:   result.ErrorCode,
-				Description: DescriptionMap[r.config.Language],
-			})
-		case domain.ErrorKeyNotFound:
-			var sideAlias string
-			if result.FindNilSide() == "LHS" {
-				sideAlias = r.config.LHSAlias
-			} else {
-				sideAlias = r.config.RHSAlias
-			}
+		reports = append(reports, report)
+	}
 
-			DescriptionMap := map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("키가 존재하지 않습니다. %s", sideAlias),
-				EN: fmt.Sprintf("Key not found. %s", sideAlias),
-			}
+	for i := range reports {
+		reports[i].Group = results[i].Group
+	}
 
-			reports = append(reports, domain.Report{
-				Key:         result.Key,
-				ErrorCode:   result.ErrorCode,
-				Description: DescriptionMap[r.config.Language],
-			})
-
-		case domain.ErrorIndexNotFound:
-			var sideAlias string
-			if result.FindNilSide() == "LHS" {
-				sideAlias = r.config.LHSAlias
-			} else {
-				sideAlias = r.config.RHSAlias
-			}
+	return reports, nil
+}
 
-			DescriptionMap := map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("인덱스가 존재하지 않습니다. %s", sideAlias),
-				EN: fmt.Sprintf("Index not found. %s", sideAlias),
-			}
+// reportGroups 는 묶음을 json 형식의 중첩된 묶음으로 변환합니다.
+func (r reporter) reportGroups(groups []resultGroup) ([]domain.ReportGroup, error) {
+	reportGroups := make([]domain.ReportGroup, 0, len(groups))
+	for _, group := range groups {
+		reports, err := r.reports(group.results)
+		if err != nil {
+			return nil, err
+		}
 
-			reports = append(reports, domain.Report{
-				Key:         result.Key,
-				ErrorCode:   result.ErrorCode,
-				Description: DescriptionMap[r.config.Language],
-			})
-		default:
-			return "", errors.New("unsupported error code")
+		children, err := r.reportGroups(group.children)
+		if err != nil {
+			return nil, err
 		}
+
+		reportGroups = append(reportGroups, domain.ReportGroup{
+			Name:    group.name,
+			Reports: reports,
+			Groups:  children,
+		})
 	}
 
-	reportJson, err := json.Marshal(domain.ReportResponse{Reports: reports})
+	return reportGroups, nil
+}
+
+// reportEntry 는 리포트에 비교한 값을 함께 담은 항목으로, 값을 직접 출력하는 html 형식과 사용자 템플릿에서 사용합니다.
+type reportEntry struct {
+	domain.Report
+	LHS  domain.YAMLEntry
+	RHS  domain.YAMLEntry
+	Base domain.YAMLEntry
+}
+
+func (r reporter) reportEntries(results domain.ErrorResults) ([]reportEntry, error) {
+	reports, err := r.reports(results)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(reportJson), nil
+	entries := make([]reportEntry, 0, len(results))
+	for idx, report := range reports {
+		entries = append(entries, reportEntry{
+			Report: report,
+			LHS:    results[idx].LHS,
+			RHS:    results[idx].RHS,
+			Base:   results[idx].Base,
+		})
+	}
+
+	return entries, nil
 }
 
 func (r reporter) generateMarkdownReport(results domain.ErrorResults) (string, error) {
-	report := "## Difference Report\n\n"
+	if lo.ContainsBy(results, domain.ErrorResult.IsThreeWay) {
+		return r.generateThreeWayMarkdownReport(results)
+	}
+
+	report := "## Difference Report\n\n" + r.generateMarkdownSummary(results)
+	if r.config.SummaryOnly {
+		return report, nil
+	}
 
-	report += fmt.Sprintf("| Key | Error Code | %s | %s | Description |\n",
+	report += "\n"
+	groups := r.groups(results)
+	if groups == nil {
+		table, err := r.generateMarkdownTable(results)
+		return report + table, err
+	}
+
+	tree, err := r.generateMarkdownTree(groups, 3)
+	return report + tree, err
+}
+
+// generateMarkdownTree 는 묶음을 level 수준의 제목으로, 하위 묶음은 한 단계 아래 수준의 제목으로 출력합니다. 제목은 h6 까지 사용합니다.
+func (r reporter) generateMarkdownTree(groups []resultGroup, level int) (string, error) {
+	report := ""
+	for _, group := range groups {
+		if group.name != "" {
+			report += fmt.Sprintf("%s `%s`\n\n", strings.Repeat("#", min(level, 6)), group.name)
+		}
+
+		if len(group.results) > 0 {
+			table, err := r.generateMarkdownTable(group.results)
+			if err != nil {
+				return "", err
+			}
+			report += table + "\n"
+		}
+
+		children, err := r.generateMarkdownTree(group.children, level+1)
+		if err != nil {
+			return "", err
+		}
+		report += children
+	}
+
+	return report, nil
+}
+
+func (r reporter) generateMarkdownTable(results domain.ErrorResults) (string, error) {
+	report := fmt.Sprintf("| Key | Error Code | Severity | %s | %s | Description |\n",
 		r.config.LHSAlias, r.config.RHSAlias,
 	)
-	report += "| --- | --- | --- | --- | --- |\n"
+	report += "| --- | --- | --- | --- | --- | --- |\n"
 
-	var descriptionMap map[domain.ReportLanguage]string
 	for _, result := range results {
-		switch result.ErrorCode {
-		case domain.ErrorTypeUnmatched:
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: "타입이 일치하지 않습니다. ",
-				EN: "Type unmatched.",
-			}
-		case domain.ErrorValueUnmatched:
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: "값이 일치하지 않습니다.",
-				EN: "Value unmatched.",
-			}
-		case domain.ErrorKeyNotFound:
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: "키가 존재하지 않습니다.",
-				EN: "Key not found.",
-			}
+		description, err := r.messages.Code(result.ErrorCode, catalog.Title, r.messageArgs(result))
+		if err != nil {
+			return "", err
+		}
 
-		case domain.ErrorIndexNotFound:
-			descriptionMap = map[domain.ReportLanguage]string{
-				KO: fmt.Sprintf("인덱스가 존재하지 않습니다."),
-				EN: fmt.Sprintf("Index not found."),
-			}
-		default:
-			return "", errors.New("unsupported error code")
+		report += fmt.Sprintf("| `%s` | `%s` | %s | %s | %s | %s |\n",
+			result.Key, result.ErrorCode, result.Severity, markdownEntry(result.LHS), markdownEntry(result.RHS), description,
+		)
+	}
+
+	return report, nil
+}
+
+// threeWaySections 는 3-way 마크다운 리포트의 섹션 순서와 제목입니다.
+var threeWaySections = []struct {
+	code  domain.ErrorCode
+	title string
+}{
+	{code: domain.ErrorThreeWayConflict, title: "Conflicts"},
+	{code: domain.ErrorChangedInLHS, title: "Changed in %s"},
+	{code: domain.ErrorChangedInRHS, title: "Changed in %s"},
+	{code: domain.ErrorChangedIdentically, title: "Changed Identically"},
+}
+
+func (r reporter) generateThreeWayMarkdownReport(results domain.ErrorResults) (string, error) {
+	report := "## Three-way Difference Report\n\n" + r.generateMarkdownSummary(results)
+	if r.config.SummaryOnly {
+		return report, nil
+	}
+
+	for _, section := range threeWaySections {
+		sectionResults := lo.Filter(results, func(result domain.ErrorResult, _ int) bool {
+			return result.ErrorCode == section.code
+		})
+		if len(sectionResults) == 0 {
+			continue
 		}
 
-		report += fmt.Sprintf("| `%s` | `%s` | `(%s)%s` | `(%s)%s` | %s |\n",
-			result.Key, result.ErrorCode, result.LHS.Type, result.LHS.Value, result.RHS.Type, result.RHS.Value, descriptionMap[r.config.Language],
+		title := section.title
+		switch section.code {
+		case domain.ErrorChangedInLHS:
+			title = fmt.Sprintf(title, r.config.LHSAlias)
+		case domain.ErrorChangedInRHS:
+			title = fmt.Sprintf(title, r.config.RHSAlias)
+		}
+
+		report += fmt.Sprintf("\n### %s\n\n", title)
+		report += fmt.Sprintf("| Key | Severity | %s | %s | %s |\n",
+			r.config.BaseAlias, r.config.LHSAlias, r.config.RHSAlias,
 		)
+		report += "| --- | --- | --- | --- | --- |\n"
+
+		for _, result := range sectionResults {
+			report += fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n",
+				result.Key, result.Severity, markdownEntry(result.Base), markdownEntry(result.LHS), markdownEntry(result.RHS),
+			)
+		}
+	}
+
+	others := lo.Reject(results, func(result domain.ErrorResult, _ int) bool {
+		return result.IsThreeWay()
+	})
+	if len(others) > 0 {
+		report += "\n### Others\n\n"
+		report += fmt.Sprintf("| Key | Error Code | Severity | %s | %s |\n", r.config.LHSAlias, r.config.RHSAlias)
+		report += "| --- | --- | --- | --- | --- |\n"
+
+		for _, result := range others {
+			report += fmt.Sprintf("| `%s` | `%s` | %s | %s | %s |\n",
+				result.Key, result.ErrorCode, result.Severity, markdownEntry(result.LHS), markdownEntry(result.RHS),
+			)
+		}
 	}
 
 	return report, nil
 }
 
+func markdownEntry(entry domain.YAMLEntry) string {
+	if entry.Type == "" {
+		return "-"
+	}
+
+	cell := fmt.Sprintf("`(%s)%s`", entry.Type, entry.Value)
+	if entry.Subtree != nil {
+		// 표 안에서는 줄바꿈을 쓸 수 없으므로 <pre> 안에서 <br> 로 줄을 나눕니다.
+		lines := strings.Split(subtreeYAML(entry.Subtree), "\n")
+		for idx, line := range lines {
+			lines[idx] = strings.ReplaceAll(html.EscapeString(line), "|", "&#124;")
+		}
+		cell = fmt.Sprintf("`%s`<pre>%s</pre>", subtreeLabel(entry), strings.Join(lines, "<br>"))
+	}
+	if entry.Source != "" {
+		cell += fmt.Sprintf("<br>_%s_", entry.Source)
+	}
+
+	return cell
+}
+
+func (r reporter) sideAlias(side string) string {
+	if side == "LHS" {
+		return r.config.LHSAlias
+	}
+
+	return r.config.RHSAlias
+}
+
+// violationDetail 은 스키마 위반 메시지나 OpenAPI 변경 분류 사유가 있는 경우 설명 뒤에 붙일 문자열을 반환합니다.
+func violationDetail(result domain.ErrorResult) string {
+	if result.Message == "" {
+		return ""
+	}
+
+	return " " + result.Message
+}
+
+// messageArgs 는 결과의 메시지에서 치환할 값을 반환합니다.
+// side 는 키가 없거나(KEY_NOT_FOUND 등), 스키마를 위반했거나, 3-way 비교에서 변경된 쪽의 별칭입니다.
+func (r reporter) messageArgs(result domain.ErrorResult) catalog.Args {
+	args := catalog.Args{
+		"key":       result.Key,
+		"lhs":       r.config.LHSAlias,
+		"rhs":       r.config.RHSAlias,
+		"base":      r.config.BaseAlias,
+		"lhsType":   result.LHS.Type,
+		"lhsValue":  result.LHS.Value,
+		"rhsType":   result.RHS.Type,
+		"rhsValue":  result.RHS.Value,
+		"baseType":  result.Base.Type,
+		"baseValue": result.Base.Value,
+		"detail":    violationDetail(result),
+	}
+
+	switch result.ErrorCode {
+	case domain.ErrorKeyNotFound, domain.ErrorIndexNotFound, domain.ErrorDefaultEqual:
+		args["side"] = r.sideAlias(result.FindNilSide())
+	case domain.ErrorSchemaViolated:
+		args["side"] = r.sideAlias(result.ViolatedSide())
+	case domain.ErrorChangedInLHS:
+		args["side"], args["sideType"], args["sideValue"] = r.config.LHSAlias, result.LHS.Type, result.LHS.Value
+	case domain.ErrorChangedInRHS:
+		args["side"], args["sideType"], args["sideValue"] = r.config.RHSAlias, result.RHS.Type, result.RHS.Value
+	}
+
+	return args
+}
+
+// sourceSuffix 는 레이어 출처가 기록된 경우 plain 리포트 끝에 붙일 문자열을 반환합니다.
+func (r reporter) sourceSuffix(result domain.ErrorResult) string {
+	var sources []string
+	if result.LHS.Source != "" {
+		sources = append(sources, fmt.Sprintf("%s: %s", r.config.LHSAlias, result.LHS.Source))
+	}
+	if result.RHS.Source != "" {
+		sources = append(sources, fmt.Sprintf("%s: %s", r.config.RHSAlias, result.RHS.Source))
+	}
+
+	if len(sources) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s)", strings.Join(sources, ", "))
+}
+
 func (r reporter) printReport(report string) {
 	fmt.Println(report)
 }
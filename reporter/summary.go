@@ -0,0 +1,115 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+// summarySeverities 는 요약에 심각도를 출력하는 순서입니다.
+var summarySeverities = []domain.Severity{
+	domain.SeverityCritical,
+	domain.SeverityError,
+	domain.SeverityWarning,
+	domain.SeverityInfo,
+}
+
+// summaryCount 는 요약의 한 항목(에러 코드, 심각도, 최상위 키)과 결과 수입니다.
+type summaryCount struct {
+	name  string
+	count int
+}
+
+// summaryCounts 는 요약을 에러 코드(이름순), 심각도(높은 순), 최상위 키(이름순)별 항목으로 나눕니다.
+func summaryCounts(summary domain.Summary) (codes []summaryCount, severities []summaryCount, topLevels []summaryCount) {
+	for code, count := range summary.ByCode {
+		codes = append(codes, summaryCount{name: string(code), count: count})
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].name < codes[j].name })
+
+	for _, severity := range summarySeverities {
+		if count := summary.BySeverity[severity]; count > 0 {
+			severities = append(severities, summaryCount{name: string(severity), count: count})
+		}
+	}
+
+	for key, count := range summary.ByTopLevel {
+		topLevels = append(topLevels, summaryCount{name: key, count: count})
+	}
+	sort.Slice(topLevels, func(i, j int) bool { return topLevels[i].name < topLevels[j].name })
+
+	return codes, severities, topLevels
+}
+
+func (r reporter) generateMarkdownSummary(results domain.ErrorResults) string {
+	summary := results.Summarize(r.config.Stats)
+	codes, severities, topLevels := summaryCounts(summary)
+
+	report := "### Summary\n\n"
+	if summary.Stats == nil {
+		report += fmt.Sprintf("| Total |\n| --- |\n| %d |\n", summary.Total)
+	} else {
+		report += "| Total | Keys Visited | Arrays Compared | Ignored Paths |\n"
+		report += "| --- | --- | --- | --- |\n"
+		report += fmt.Sprintf("| %d | %d | %d | %d |\n",
+			summary.Total, summary.Stats.KeysVisited, summary.Stats.ArraysCompared, summary.Stats.IgnoredPaths,
+		)
+	}
+
+	for _, table := range []struct {
+		title  string
+		format string
+		counts []summaryCount
+	}{
+		{title: "Error Code", format: "| `%s` | %d |\n", counts: codes},
+		{title: "Severity", format: "| %s | %d |\n", counts: severities},
+		{title: "Top-level Key", format: "| `%s` | %d |\n", counts: topLevels},
+	} {
+		if len(table.counts) == 0 {
+			continue
+		}
+
+		report += fmt.Sprintf("\n| %s | Count |\n| --- | --- |\n", table.title)
+		for _, count := range table.counts {
+			report += fmt.Sprintf(table.format, count.name, count.count)
+		}
+	}
+
+	return report
+}
+
+func (r reporter) generatePlainTextSummary(results domain.ErrorResults) string {
+	summary := results.Summarize(r.config.Stats)
+	codes, severities, topLevels := summaryCounts(summary)
+
+	report := fmt.Sprintf("Summary: %d differences", summary.Total)
+	if summary.Stats != nil {
+		report += fmt.Sprintf(", %d keys visited, %d arrays compared, %d ignored paths",
+			summary.Stats.KeysVisited, summary.Stats.ArraysCompared, summary.Stats.IgnoredPaths,
+		)
+	}
+	report += "\n"
+
+	for _, line := range []struct {
+		title  string
+		counts []summaryCount
+	}{
+		{title: "error code", counts: codes},
+		{title: "severity", counts: severities},
+		{title: "top-level key", counts: topLevels},
+	} {
+		if len(line.counts) == 0 {
+			continue
+		}
+
+		items := make([]string, 0, len(line.counts))
+		for _, count := range line.counts {
+			items = append(items, fmt.Sprintf("%s %d", count.name, count.count))
+		}
+		report += fmt.Sprintf("- %s: %s\n", line.title, strings.Join(items, ", "))
+	}
+
+	return report
+}
@@ -0,0 +1,135 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	htmltemplate "html/template"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateData 는 사용자 템플릿의 최상위 값(.)입니다.
+type templateData struct {
+	Results   []reportEntry
+	Summary   domain.Summary
+	LHSAlias  string
+	RHSAlias  string
+	BaseAlias string
+	Language  domain.ReportLanguage
+}
+
+// templateFuncs 는 사용자 템플릿에서 사용할 수 있는 함수입니다.
+var templateFuncs = map[string]any{
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+	"trim":      strings.TrimSpace,
+	"replace":   strings.ReplaceAll,
+	"hasPrefix": strings.HasPrefix,
+	"repeat":    strings.Repeat,
+	"join":      strings.Join,
+	"split":     strings.Split,
+	"add":       func(a int, b int) int { return a + b },
+	"topLevel":  topLevelKey,
+	"json": func(value any) (string, error) {
+		out, err := json.Marshal(value)
+		return string(out), err
+	},
+	"yaml": func(value any) (string, error) {
+		out, err := yaml.Marshal(value)
+		return strings.TrimSuffix(string(out), "\n"), err
+	},
+	"indent": func(spaces int, text string) string {
+		pad := strings.Repeat(" ", spaces)
+		return pad + strings.ReplaceAll(text, "\n", "\n"+pad)
+	},
+	"groupBy": groupEntries,
+}
+
+// templateGroup 은 groupBy 함수가 반환하는 결과 묶음입니다.
+type templateGroup struct {
+	Name    string
+	Results []reportEntry
+}
+
+// groupEntries 는 결과를 field(code, severity, group, source, top) 기준으로 묶어 이름순으로 반환합니다.
+func groupEntries(field string, entries []reportEntry) []templateGroup {
+	var (
+		names   []string
+		grouped = map[string][]reportEntry{}
+	)
+
+	for _, entry := range entries {
+		var name string
+		switch field {
+		case "code":
+			name = string(entry.ErrorCode)
+		case "severity":
+			name = string(entry.Severity)
+		case "group":
+			name = entry.Group
+		case "source":
+			name = htmlSectionName(entry)
+		case "top":
+			name = topLevelKey(entry.Key)
+		}
+
+		if _, ok := grouped[name]; !ok {
+			names = append(names, name)
+		}
+		grouped[name] = append(grouped[name], entry)
+	}
+	sort.Strings(names)
+
+	groups := make([]templateGroup, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, templateGroup{Name: name, Results: grouped[name]})
+	}
+
+	return groups
+}
+
+// generateTemplateReport 는 사용자가 지정한 Go 템플릿 파일로 리포트를 생성합니다.
+// 확장자가 .html 인 템플릿은 값이 자동으로 이스케이프되도록 html/template 으로 처리합니다.
+func (r reporter) generateTemplateReport(results domain.ErrorResults) (string, error) {
+	entries, err := r.reportEntries(results)
+	if err != nil {
+		return "", err
+	}
+
+	data := templateData{
+		Results:   entries,
+		Summary:   results.Summarize(r.config.Stats),
+		LHSAlias:  r.config.LHSAlias,
+		RHSAlias:  r.config.RHSAlias,
+		BaseAlias: r.config.BaseAlias,
+		Language:  r.config.Language,
+	}
+
+	var tmpl interface {
+		Execute(w io.Writer, data any) error
+	}
+
+	name := filepath.Base(r.config.TemplatePath)
+	if strings.EqualFold(filepath.Ext(name), ".html") {
+		tmpl, err = htmltemplate.New(name).Funcs(templateFuncs).ParseFiles(r.config.TemplatePath)
+	} else {
+		tmpl, err = template.New(name).Funcs(templateFuncs).ParseFiles(r.config.TemplatePath)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
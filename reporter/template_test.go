@@ -0,0 +1,57 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reporter_generateTemplateReport(t *testing.T) {
+	results := domain.ErrorResults{
+		domain.ValueUnmatchedResult("app.image", "app:1", "<script>alert(1)</script>"),
+		domain.KeyNotFoundResult("db.host", "localhost", nil),
+		domain.ValueUnmatchedResult("replicas", 1, 2),
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		template string
+		want     string
+	}{
+		{
+			name:     "text 템플릿은 값을 그대로 출력",
+			file:     "report.tmpl",
+			template: `{{range .Results}}{{.Key}}={{.RHS.Value}};{{end}}`,
+			want:     `app.image=<script>alert(1)</script>;db.host=null;replicas=2;`,
+		},
+		{
+			name:     "html 템플릿은 값을 이스케이프",
+			file:     "report.html",
+			template: `<p>{{(index .Results 0).RHS.Value}}</p>`,
+			want:     `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`,
+		},
+		{
+			name: "groupBy 와 도우미 함수",
+			file: "report.tmpl",
+			template: `{{range groupBy "code" .Results}}{{.Name | lower}}:{{range .Results}} {{topLevel .Key | upper}}{{end}}
+{{end}}{{.LHSAlias}} {{add .Summary.Total 1}} {{join (split "a.b" ".") "/"}}`,
+			want: "key_not_found: DB\nvalue_unmatched: APP REPLICAS\nlhs 4 a/b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			assert.NoError(t, os.WriteFile(path, []byte(tt.template), 0644))
+
+			r := New(Config{LHSAlias: "lhs", RHSAlias: "rhs", TemplatePath: path}).(reporter)
+			got, err := r.generateTemplateReport(results)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/catalog"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+const (
+	SideBySideView domain.DiffView = "side-by-side"
+	UnifiedView    domain.DiffView = "unified"
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+	ansiDim   = "\x1b[2m"
+)
+
+// defaultTerminalWidth 는 COLUMNS 환경 변수가 없을 때 사용할 side-by-side 출력 너비입니다.
+const defaultTerminalWidth = 160
+
+func NewDiffView(view string) (domain.DiffView, error) {
+	switch domain.DiffView(view) {
+	case SideBySideView, UnifiedView:
+		return domain.DiffView(view), nil
+	default:
+		return "", fmt.Errorf("unsupported diff view: %s", view)
+	}
+}
+
+// colorEnabled 는 색상을 사용할지 확인합니다.
+// 파일로 출력하거나, 표준 출력이 터미널이 아니거나, NO_COLOR 환경 변수가 지정된 경우 색상을 사용하지 않습니다.
+func (r reporter) colorEnabled() bool {
+	if r.config.OutputType != Stdout || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (r reporter) paint(color string, text string) string {
+	if !r.color {
+		return text
+	}
+
+	return color + text + ansiReset
+}
+
+// generateTerminalReport 는 두 문서를 정규화한 YAML 을 줄 단위로 비교하여 변경된 줄을 색상으로 표시합니다.
+// 무시된 경로처럼 비교 결과와 관련 없는 변경은 출력하지 않습니다.
+// 변경되지 않은 줄은 변경 앞뒤 Context 줄만 남기고 접습니다.
+func (r reporter) generateTerminalReport(results domain.ErrorResults) (string, error) {
+	lhs, rhs := canonicalize(r.config.LHSDocument), canonicalize(r.config.RHSDocument)
+	edits := diffLines(lhs, rhs)
+
+	report := r.paint(ansiRed, "--- "+r.config.LHSAlias) + "\n" + r.paint(ansiGreen, "+++ "+r.config.RHSAlias) + "\n"
+
+	include := resultFilter(results, lhs, rhs)
+	previous := 0
+	for _, h := range hunks(edits, r.config.Context, include) {
+		report += r.foldedLines(edits[previous:h.start])
+		previous = h.start + len(h.edits)
+
+		report += r.paint(ansiCyan, fmt.Sprintf("@@ %s @@", hunkPath(h, lhs, rhs, include))) + "\n"
+		if r.config.View == UnifiedView {
+			report += r.unifiedHunk(h, lhs, rhs, include)
+		} else {
+			report += r.sideBySideHunk(h, lhs, rhs, include)
+		}
+	}
+	report += r.foldedLines(edits[previous:])
+
+	return report + r.messages.Message("differences", catalog.Args{"count": strconv.Itoa(len(results))}) + "\n", nil
+}
+
+// foldedLines 는 접힌 구간의 lhs 줄 수를 표시합니다. 비교 결과와 관련 없는 변경도 함께 접습니다.
+func (r reporter) foldedLines(edits []edit) string {
+	count := 0
+	for _, e := range edits {
+		if e.lhs >= 0 {
+			count++
+		}
+	}
+	if count <= 0 {
+		return ""
+	}
+
+	return r.paint(ansiDim, fmt.Sprintf("⋯ %d unchanged lines", count)) + "\n"
+}
+
+func (r reporter) unifiedHunk(h hunk, lhs []canonicalLine, rhs []canonicalLine, include func(edit) bool) string {
+	var report string
+	for _, e := range h.edits {
+		if e.kind != editEqual && !include(e) {
+			continue
+		}
+
+		switch e.kind {
+		case editDelete:
+			report += r.paint(ansiRed, "-"+lhs[e.lhs].text) + "\n"
+		case editInsert:
+			report += r.paint(ansiGreen, "+"+rhs[e.rhs].text) + "\n"
+		default:
+			report += " " + lhs[e.lhs].text + "\n"
+		}
+	}
+
+	return report
+}
+
+// sideBySideHunk 는 구간을 좌우로 나란히 출력합니다. 연속한 삭제와 추가는 같은 행에 짝지어 출력합니다.
+func (r reporter) sideBySideHunk(h hunk, lhs []canonicalLine, rhs []canonicalLine, include func(edit) bool) string {
+	width := (terminalWidth() - 15) / 2
+
+	var (
+		report  string
+		deleted []edit
+		added   []edit
+	)
+	flush := func() {
+		for idx := 0; idx < len(deleted) || idx < len(added); idx++ {
+			left, right := r.sideCell(-1, "", width, ansiRed), r.sideCell(-1, "", width, ansiGreen)
+			if idx < len(deleted) {
+				e := deleted[idx]
+				left = r.sideCell(e.lhs, lhs[e.lhs].text, width, ansiRed)
+			}
+			if idx < len(added) {
+				e := added[idx]
+				right = r.sideCell(e.rhs, rhs[e.rhs].text, width, ansiGreen)
+			}
+			report += left + " │ " + right + "\n"
+		}
+		deleted, added = nil, nil
+	}
+
+	for _, e := range h.edits {
+		if e.kind != editEqual && !include(e) {
+			continue
+		}
+
+		switch e.kind {
+		case editDelete:
+			deleted = append(deleted, e)
+		case editInsert:
+			added = append(added, e)
+		default:
+			flush()
+			report += r.sideCell(e.lhs, lhs[e.lhs].text, width, "") + " │ " + r.sideCell(e.rhs, rhs[e.rhs].text, width, "") + "\n"
+		}
+	}
+	flush()
+
+	return report
+}
+
+// sideCell 은 줄 번호와 내용을 width 너비에 맞춰 출력합니다. 줄이 없는 쪽(line < 0)은 빈 칸으로 출력합니다.
+func (r reporter) sideCell(line int, text string, width int, color string) string {
+	if line < 0 {
+		return strings.Repeat(" ", width+6)
+	}
+
+	if utf8.RuneCountInString(text) > width {
+		text = string([]rune(text)[:width-1]) + "…"
+	}
+	cell := fmt.Sprintf("%5d %s%s", line+1, text, strings.Repeat(" ", width-utf8.RuneCountInString(text)))
+	if color == "" {
+		return cell
+	}
+
+	return r.paint(color, cell)
+}
+
+// hunkPath 는 구간에서 변경된 줄의 공통 상위 경로를 반환합니다.
+func hunkPath(h hunk, lhs []canonicalLine, rhs []canonicalLine, include func(edit) bool) string {
+	var paths []string
+	for _, e := range h.edits {
+		if e.kind != editEqual && !include(e) {
+			continue
+		}
+
+		switch e.kind {
+		case editDelete:
+			paths = append(paths, lhs[e.lhs].path)
+		case editInsert:
+			paths = append(paths, rhs[e.rhs].path)
+		}
+	}
+
+	if path := commonPath(paths); path != "" {
+		return path
+	}
+
+	return "(root)"
+}
+
+func terminalWidth() int {
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 40 {
+		return width
+	}
+
+	return defaultTerminalWidth
+}
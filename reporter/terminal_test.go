@@ -0,0 +1,28 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/catalog"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reporter_generateTerminalReport(t *testing.T) {
+	r := reporter{
+		config: Config{
+			LHSAlias:    "lhs",
+			RHSAlias:    "rhs",
+			LHSDocument: map[string]any{"a": 1, "foo": "x", "z": 1},
+			RHSDocument: map[string]any{"a": 2, "foo": "y", "z": 1},
+			View:        UnifiedView,
+			Context:     1,
+		},
+		messages: catalog.Default(catalog.DefaultLanguage),
+	}
+
+	got, err := r.generateTerminalReport(domain.ErrorResults{{Key: "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "--- lhs\n+++ rhs\n@@ a @@\n-a: 1\n+a: 2\n z: 1\n1 differences found.\n", got)
+}
@@ -0,0 +1,72 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+)
+
+// generateUnifiedReport 는 두 문서를 정규화한 YAML 의 unified diff 를 생성합니다.
+// 비교 결과의 경로를 포함한 구간만 출력하며, 구간 머리글에는 함수 이름 대신 YAML 경로를 표시합니다.
+func (r reporter) generateUnifiedReport(results domain.ErrorResults) (string, error) {
+	lhs, rhs := canonicalize(r.config.LHSDocument), canonicalize(r.config.RHSDocument)
+	edits := diffLines(lhs, rhs)
+
+	include := resultFilter(results, lhs, rhs)
+	report := fmt.Sprintf("--- %s\n+++ %s\n", r.config.LHSAlias, r.config.RHSAlias)
+	for _, h := range hunks(edits, r.config.Context, include) {
+		lhsStart, lhsCount, rhsStart, rhsCount := h.lineRange(edits)
+		report += fmt.Sprintf("@@ -%d,%d +%d,%d @@ %s\n", lhsStart, lhsCount, rhsStart, rhsCount, hunkPath(h, lhs, rhs, include))
+
+		for _, e := range h.edits {
+			switch e.kind {
+			case editDelete:
+				report += "-" + lhs[e.lhs].text + "\n"
+			case editInsert:
+				report += "+" + rhs[e.rhs].text + "\n"
+			default:
+				report += " " + lhs[e.lhs].text + "\n"
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// resultFilter 는 비교 결과의 경로와 관련된 변경인지 확인하는 hunks 의 include 함수를 반환합니다.
+// 무시된 경로나 순서만 바뀐 unordered 배열처럼 비교 결과에 없는 변경은 출력하지 않습니다.
+func resultFilter(results domain.ErrorResults, lhs []canonicalLine, rhs []canonicalLine) func(edit) bool {
+	keys := make([]string, 0, len(results))
+	for _, result := range results {
+		keys = append(keys, result.Key)
+	}
+
+	return func(e edit) bool {
+		path := rhs[max(e.rhs, 0)].path
+		if e.kind == editDelete {
+			path = lhs[e.lhs].path
+		}
+
+		for _, key := range keys {
+			if relatedPath(path, key) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// relatedPath 는 두 경로가 같거나 한쪽이 다른 쪽의 상위 경로인지 확인합니다.
+func relatedPath(path string, key string) bool {
+	return path == key || isDescendant(path, key) || isDescendant(key, path)
+}
+
+func isDescendant(path string, ancestor string) bool {
+	if ancestor == "" {
+		return true
+	}
+
+	return strings.HasPrefix(path, ancestor+".") || strings.HasPrefix(path, ancestor+"[")
+}
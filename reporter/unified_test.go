@@ -0,0 +1,83 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reporter_generateUnifiedReport(t *testing.T) {
+	base := map[string]any{
+		"app":  map[string]any{"image": "a", "port": 80},
+		"name": "x",
+	}
+
+	tests := []struct {
+		name    string
+		rhs     map[string]any
+		keys    []string
+		context int
+		want    string
+	}{
+		{
+			name: "값 변경",
+			rhs: map[string]any{
+				"app":  map[string]any{"image": "b", "port": 80},
+				"name": "x",
+			},
+			keys:    []string{"app.image"},
+			context: 1,
+			want:    "--- lhs\n+++ rhs\n@@ -1,3 +1,3 @@ app.image\n app:\n-  image: a\n+  image: b\n   port: 80\n",
+		},
+		{
+			name: "키 추가",
+			rhs: map[string]any{
+				"app":  map[string]any{"image": "a", "port": 80, "tag": "v"},
+				"name": "x",
+			},
+			keys:    []string{"app.tag"},
+			context: 0,
+			want:    "--- lhs\n+++ rhs\n@@ -3,0 +4,1 @@ app.tag\n+  tag: v\n",
+		},
+		{
+			name: "키 삭제",
+			rhs: map[string]any{
+				"app": map[string]any{"image": "a", "port": 80},
+			},
+			keys:    []string{"name"},
+			context: 0,
+			want:    "--- lhs\n+++ rhs\n@@ -4,1 +3,0 @@ name\n-name: x\n",
+		},
+		{
+			name: "비교 결과에 없는 변경은 제외",
+			rhs: map[string]any{
+				"app": map[string]any{"image": "b", "port": 80},
+			},
+			keys:    []string{"name"},
+			context: 0,
+			want:    "--- lhs\n+++ rhs\n@@ -4,1 +3,0 @@ name\n-name: x\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := reporter{config: Config{
+				LHSAlias:    "lhs",
+				RHSAlias:    "rhs",
+				LHSDocument: base,
+				RHSDocument: tt.rhs,
+				Context:     tt.context,
+			}}
+
+			var results domain.ErrorResults
+			for _, key := range tt.keys {
+				results = append(results, domain.ErrorResult{Key: key})
+			}
+
+			got, err := r.generateUnifiedReport(results)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
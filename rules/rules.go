@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Loader interface {
+	Load() (domain.Rules, error)
+}
+
+type Config struct {
+	Path string
+}
+
+type loader struct {
+	config Config
+}
+
+func New(config Config) Loader {
+	return loader{config: config}
+}
+
+func (l loader) Load() (domain.Rules, error) {
+	var file domain.RuleFile
+
+	content, err := os.ReadFile(l.config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	for idx, rule := range file.Rules {
+		if len(rule.Paths) == 0 {
+			return nil, fmt.Errorf("rule %d: paths is required", idx)
+		}
+
+		if rule.Severity != "" {
+			if _, err = domain.NewSeverity(string(rule.Severity)); err != nil {
+				return nil, fmt.Errorf("rule %d: %w", idx, err)
+			}
+		}
+
+		switch rule.ArrayStrategy {
+		case "", comparer.IndexStrategy, comparer.UnorderedStrategy:
+		case comparer.KeyStrategy:
+			if rule.ArrayKey == "" {
+				return nil, fmt.Errorf("rule %d: arrayKey is required for key strategy", idx)
+			}
+		default:
+			return nil, fmt.Errorf("rule %d: unsupported array strategy %s", idx, rule.ArrayStrategy)
+		}
+	}
+
+	return file.Rules, nil
+}
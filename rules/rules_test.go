@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/comparer"
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loader_Load(t *testing.T) {
+	tolerance := 0.1
+
+	tests := []struct {
+		name    string
+		content string
+		want    domain.Rules
+		wantErr string
+	}{
+		{
+			name: "규칙 파일",
+			content: `rules:
+  - paths: ["spec.containers"]
+    arrayStrategy: key
+    arrayKey: name
+  - paths: ["resources.**.cpu"]
+    tolerance: 0.1
+    severity: error
+`,
+			want: domain.Rules{
+				{Paths: []string{"spec.containers"}, ArrayStrategy: comparer.KeyStrategy, ArrayKey: "name"},
+				{Paths: []string{"resources.**.cpu"}, Tolerance: &tolerance, Severity: domain.SeverityError},
+			},
+		},
+		{
+			name:    "paths 누락",
+			content: "rules:\n  - severity: error\n",
+			wantErr: "rule 0: paths is required",
+		},
+		{
+			name:    "key 전략의 arrayKey 누락",
+			content: "rules:\n  - paths: [a]\n  - paths: [b]\n    arrayStrategy: key\n",
+			wantErr: "rule 1: arrayKey is required for key strategy",
+		},
+		{
+			name:    "지원하지 않는 배열 전략",
+			content: "rules:\n  - paths: [a]\n    arrayStrategy: sorted\n",
+			wantErr: "rule 0: unsupported array strategy sorted",
+		},
+		{
+			name:    "잘못된 severity",
+			content: "rules:\n  - paths: [a]\n    severity: fatal\n",
+			wantErr: "rule 0: unsupported severity: fatal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			got, err := New(Config{Path: path}).Load()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
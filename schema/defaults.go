@@ -0,0 +1,176 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ApplyDefaults 는 문서에 없는 키를 스키마의 default 값으로 채운 새 문서와 채워진 경로를 반환합니다.
+// 기본값으로 채운 값 내부에서 다시 채워진 경로는 따로 기록하지 않습니다.
+func (v validator) ApplyDefaults(document map[string]any) (map[string]any, []string, error) {
+	sch, err := v.compile()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var filled []string
+	result, _ := applyDefaults(sch, "", copyValue(document), false, &filled).(map[string]any)
+	sort.Strings(filled)
+
+	return result, filled, nil
+}
+
+func applyDefaults(sch *jsonschema.Schema, key string, value any, inDefault bool, filled *[]string) any {
+	schemas := applicable(sch, make(map[*jsonschema.Schema]bool))
+
+	switch value := value.(type) {
+	case map[string]any:
+		for _, s := range schemas {
+			for _, name := range sortedNames(s.Properties) {
+				prop := s.Properties[name]
+				nextKey := domain.MapKey(key, name)
+
+				childInDefault := inDefault
+				if _, ok := value[name]; !ok {
+					def, ok := defaultOf(prop)
+					if !ok {
+						continue
+					}
+
+					value[name] = def
+					childInDefault = true
+					if !inDefault {
+						*filled = append(*filled, nextKey)
+					}
+				}
+
+				value[name] = applyDefaults(prop, nextKey, value[name], childInDefault, filled)
+			}
+		}
+		return value
+	case []any:
+		for _, s := range schemas {
+			for idx := range value {
+				item := s.Items2020
+				if idx < len(s.PrefixItems) {
+					item = s.PrefixItems[idx]
+				}
+				if item == nil {
+					continue
+				}
+
+				value[idx] = applyDefaults(item, domain.SliceKey(key, idx), value[idx], inDefault, filled)
+			}
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// applicable 은 같은 위치에 적용되는 스키마를 $ref 와 allOf 를 따라가며 모읍니다.
+func applicable(sch *jsonschema.Schema, visited map[*jsonschema.Schema]bool) []*jsonschema.Schema {
+	if sch == nil || visited[sch] {
+		return nil
+	}
+	visited[sch] = true
+
+	schemas := []*jsonschema.Schema{sch}
+	schemas = append(schemas, applicable(sch.Ref, visited)...)
+	for _, s := range sch.AllOf {
+		schemas = append(schemas, applicable(s, visited)...)
+	}
+
+	return schemas
+}
+
+func defaultOf(sch *jsonschema.Schema) (any, bool) {
+	for _, s := range applicable(sch, make(map[*jsonschema.Schema]bool)) {
+		if s.Default != nil {
+			return copyValue(*s.Default), true
+		}
+	}
+
+	return nil, false
+}
+
+// copyValue 는 값을 복사하며 JSON 숫자를 YAML 파서와 같은 int, float64 로 변환합니다.
+func copyValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for key, val := range value {
+			result[key] = copyValue(val)
+		}
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for idx, val := range value {
+			result[idx] = copyValue(val)
+		}
+		return result
+	case json.Number:
+		if i, err := strconv.Atoi(value.String()); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	default:
+		return value
+	}
+}
+
+func sortedNames(properties map[string]*jsonschema.Schema) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Equivalents 는 한쪽에서만 기본값으로 채워진 경로 중 채워진 값이 다른 쪽의 값과 같은 경로를
+// DEFAULT_EQUIVALENT 결과로 반환합니다. lhs, rhs 는 기본값이 채워진 문서입니다.
+func Equivalents(lhs map[string]any, rhs map[string]any, lhsFilled []string, rhsFilled []string) domain.ErrorResults {
+	var results domain.ErrorResults
+	for _, side := range []struct {
+		filled []string
+		other  []string
+		isLHS  bool
+	}{
+		{filled: lhsFilled, other: rhsFilled, isLHS: true},
+		{filled: rhsFilled, other: lhsFilled, isLHS: false},
+	} {
+		for _, key := range side.filled {
+			if containsKey(side.other, key) {
+				continue
+			}
+
+			lhsVal, _ := domain.Lookup(lhs, key)
+			rhsVal, _ := domain.Lookup(rhs, key)
+			if !reflect.DeepEqual(lhsVal, rhsVal) {
+				continue
+			}
+
+			if side.isLHS {
+				results = append(results, domain.DefaultEquivalentResult(key, nil, rhsVal))
+			} else {
+				results = append(results, domain.DefaultEquivalentResult(key, lhsVal, nil))
+			}
+		}
+	}
+
+	return results
+}
+
+func containsKey(keys []string, key string) bool {
+	idx := sort.SearchStrings(keys, key)
+	return idx < len(keys) && keys[idx] == key
+}
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const defaultsSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "replicas": {"type": "integer", "default": 1},
+    "image": {"$ref": "#/$defs/image", "default": {"tag": "latest"}},
+    "ports": {"type": "array", "items": {"$ref": "#/$defs/port"}}
+  },
+  "$defs": {
+    "image": {"type": "object", "properties": {"pull": {"type": "string", "default": "IfNotPresent"}}},
+    "port": {"type": "object", "properties": {"protocol": {"type": "string", "default": "TCP"}}}
+  }
+}`
+
+func Test_validator_ApplyDefaults(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	assert.NoError(t, os.WriteFile(schemaPath, []byte(defaultsSchema), 0644))
+
+	document := map[string]any{"replicas": 3, "ports": []any{map[string]any{"port": 80}}}
+
+	got, filled, err := New(Config{Path: schemaPath}).ApplyDefaults(document)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"replicas": 3,
+		"image":    map[string]any{"tag": "latest", "pull": "IfNotPresent"},
+		"ports":    []any{map[string]any{"port": 80, "protocol": "TCP"}},
+	}, got)
+	assert.Equal(t, []string{"image", "ports[0].protocol"}, filled)
+	assert.NotContains(t, document["ports"].([]any)[0], "protocol")
+}
+
+func TestEquivalents(t *testing.T) {
+	lhs := map[string]any{"replicas": 1, "ratio": 0.5, "both": "x"}
+	rhs := map[string]any{"replicas": 1, "ratio": 0.7, "both": "x"}
+
+	got := Equivalents(lhs, rhs, []string{"both", "ratio", "replicas"}, []string{"both"})
+	assert.Equal(t, domain.ErrorResults{
+		domain.DefaultEquivalentResult("replicas", nil, 1),
+	}, got)
+}
@@ -0,0 +1,208 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const (
+	ReportDefaults   domain.DefaultsMode = "report"
+	SuppressDefaults domain.DefaultsMode = "suppress"
+	IgnoreDefaults   domain.DefaultsMode = "off"
+)
+
+type Validator interface {
+	Validate(lhs map[string]any, rhs map[string]any) (domain.ErrorResults, error)
+	ApplyDefaults(document map[string]any) (map[string]any, []string, error)
+}
+
+type Config struct {
+	Path string
+}
+
+type validator struct {
+	config Config
+}
+
+func New(config Config) Validator {
+	return validator{config: config}
+}
+
+func NewDefaultsMode(mode string) (domain.DefaultsMode, error) {
+	switch domain.DefaultsMode(mode) {
+	case ReportDefaults, SuppressDefaults, IgnoreDefaults:
+		return domain.DefaultsMode(mode), nil
+	default:
+		return "", fmt.Errorf("unsupported schema defaults mode: %s", mode)
+	}
+}
+
+var printer = message.NewPrinter(language.English)
+
+// Validate 는 lhs, rhs 문서를 JSON Schema (draft 2020-12) 로 검증하고 위반 사항을 SCHEMA_VIOLATION 결과로 반환합니다.
+// 복호화하지 못한 SOPS 값처럼 마스킹된 값에서 발생한 위반은 원본 값을 알 수 없으므로 보고하지 않습니다.
+func (v validator) Validate(lhs map[string]any, rhs map[string]any) (domain.ErrorResults, error) {
+	sch, err := v.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	var results domain.ErrorResults
+	for _, side := range []struct {
+		name     string
+		document map[string]any
+	}{
+		{name: "LHS", document: lhs},
+		{name: "RHS", document: rhs},
+	} {
+		violations, err := validate(sch, side.document)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, violation := range violations {
+			value, _ := domain.Lookup(side.document, violation.key)
+			results = append(results, domain.SchemaViolationResult(violation.key, side.name, value, violation.message))
+		}
+	}
+
+	return results, nil
+}
+
+func (v validator) compile() (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+
+	return compiler.Compile(v.config.Path)
+}
+
+type violation struct {
+	key     string
+	message string
+}
+
+func validate(sch *jsonschema.Schema, document map[string]any) ([]violation, error) {
+	masked := make(map[string]bool)
+	err := sch.Validate(unmask("", document, masked))
+	if err == nil {
+		return nil, nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var violations []violation
+	seen := make(map[violation]bool)
+	for _, leaf := range leaves(validationErr) {
+		key := instanceKey(document, leaf.InstanceLocation)
+		if masked[key] {
+			continue
+		}
+
+		for _, v := range leafViolations(key, leaf.ErrorKind) {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			violations = append(violations, v)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].key < violations[j].key
+	})
+
+	return violations, nil
+}
+
+// leafViolations 는 검증 에러를 위반 사항으로 변환합니다.
+// 필수 속성 누락은 상위 객체가 아닌 누락된 속성의 경로로 속성마다 하나씩 보고합니다.
+func leafViolations(key string, errorKind jsonschema.ErrorKind) []violation {
+	required, ok := errorKind.(*kind.Required)
+	if !ok {
+		return []violation{{key: key, message: errorKind.LocalizedString(printer)}}
+	}
+
+	violations := make([]violation, 0, len(required.Missing))
+	for _, property := range required.Missing {
+		missing := &kind.Required{Missing: []string{property}}
+		violations = append(violations, violation{key: domain.MapKey(key, property), message: missing.LocalizedString(printer)})
+	}
+
+	return violations
+}
+
+// leaves 는 중첩된 검증 에러 중 실제 위반 내용을 담은 최하위 에러를 반환합니다.
+func leaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	var result []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		result = append(result, leaves(cause)...)
+	}
+
+	return result
+}
+
+// unmask 는 마스킹된 값을 null 로 바꾼 검증용 문서를 만들고 해당 경로를 기록합니다.
+func unmask(key string, value any, masked map[string]bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for childKey, childVal := range value {
+			result[childKey] = unmask(domain.MapKey(key, childKey), childVal, masked)
+		}
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for idx, elem := range value {
+			result[idx] = unmask(domain.SliceKey(key, idx), elem, masked)
+		}
+		return result
+	case domain.MaskedValue:
+		masked[key] = true
+		return nil
+	default:
+		return value
+	}
+}
+
+// instanceKey 는 JSON Pointer 토큰을 "a.b[0].c" 형식의 경로로 변환합니다.
+func instanceKey(document any, tokens []string) string {
+	key := ""
+	current := document
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case []any:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx >= len(node) {
+				key = domain.MapKey(key, token)
+				current = nil
+				continue
+			}
+			key = domain.SliceKey(key, idx)
+			current = node[idx]
+		case map[string]any:
+			key = domain.MapKey(key, token)
+			current = node[token]
+		default:
+			key = domain.MapKey(key, token)
+			current = nil
+		}
+	}
+
+	return key
+}
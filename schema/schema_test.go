@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illuminarean-labs/yaml-diff-reporter/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string"},
+    "replicas": {"type": "integer", "minimum": 1},
+    "ports": {"type": "array", "items": {"type": "integer"}},
+    "token": {"type": "string"}
+  }
+}`
+
+func Test_validator_Validate(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	assert.NoError(t, os.WriteFile(schemaPath, []byte(testSchema), 0644))
+
+	lhs := map[string]any{
+		"name":     "api",
+		"replicas": 2,
+		"token":    domain.MaskedValue{Type: "string", Display: "[ENCRYPTED]"},
+	}
+	rhs := map[string]any{
+		"replicas": 0,
+		"ports":    []any{80, "http"},
+	}
+
+	got, err := New(Config{Path: schemaPath}).Validate(lhs, rhs)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ErrorResults{
+		domain.SchemaViolationResult("name", "RHS", nil, "missing property 'name'"),
+		domain.SchemaViolationResult("ports[1]", "RHS", "http", "got string, want integer"),
+		domain.SchemaViolationResult("replicas", "RHS", 0, "minimum: got 0, want 1"),
+	}, got)
+}
+
+func Test_instanceKey(t *testing.T) {
+	document := map[string]any{"a": []any{map[string]any{"b": 1}}}
+
+	assert.Equal(t, "a[0].b", instanceKey(document, []string{"a", "0", "b"}))
+	assert.Equal(t, "", instanceKey(document, nil))
+}